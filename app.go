@@ -4,23 +4,46 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"track-my-job-apps/internal/backup"
+	"track-my-job-apps/internal/config"
 	"track-my-job-apps/internal/database"
+	"track-my-job-apps/internal/duplicate"
 	"track-my-job-apps/internal/models"
 	"track-my-job-apps/internal/parser"
+	"track-my-job-apps/internal/paths"
+	"track-my-job-apps/internal/pipeline"
+	"track-my-job-apps/internal/profile"
+	"track-my-job-apps/internal/salary"
 )
 
 // App struct
 type App struct {
-	ctx    context.Context
-	backup *backup.BackupService
+	ctx      context.Context
+	dirs     *paths.Dirs
+	backup   *backup.BackupService
+	config   *config.Config
+	pipeline *pipeline.Pipeline
+
+	// mu guards the profiles and the active profile's store, which change
+	// when switching profiles. Calls into the store hold it for reading, so
+	// a switch waits for them before closing the store.
+	mu       sync.RWMutex
+	profiles *profile.Profiles
+	store    *database.Store
+	// switchMu serializes profile switches and closing the app
+	switchMu sync.Mutex
 }
 
-// NewApp creates a new App application struct
-func NewApp() *App {
-	return &App{}
+// NewApp creates a new App application struct keeping its files in dirs
+func NewApp(dirs *paths.Dirs) *App {
+	return &App{dirs: dirs, config: config.Default(), pipeline: pipeline.Default()}
 }
 
 // startup is called when the app starts up and can be used to
@@ -28,70 +51,704 @@ func NewApp() *App {
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 
-	// Initialize database
-	if err := database.InitDatabase(); err != nil {
+	// Load local settings (salary normalization, ...)
+	cfg, err := config.Load(a.dirs.ConfigFile())
+	if err != nil {
+		log.Printf("Warning: Failed to load config, using defaults: %v", err)
+	} else {
+		a.config = cfg
+	}
+
+	// Status pipeline with any custom stages from the config
+	statusPipeline, err := pipeline.New(a.config)
+	if err != nil {
+		log.Printf("Warning: Invalid custom statuses, using the default pipeline: %v", err)
+	} else {
+		a.pipeline = statusPipeline
+	}
+
+	// Initialize the database of the profile used last
+	profiles, err := profile.Load(a.dirs.Profiles())
+	if err != nil {
+		log.Fatalf("Failed to load profiles: %v", err)
+	}
+	store, err := a.openProfile(profiles.Get(profiles.Active))
+	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
+	a.profiles, a.store = profiles, store
 
 	// Initialize backup service (don't fail if backup setup is incomplete)
-	backupService, err := backup.NewBackupService()
+	backupService, err := backup.NewBackupService(a.dirs.Credentials(), a.dirs.Token())
 	if err != nil {
 		log.Printf("Warning: Failed to initialize backup service: %v", err)
-		log.Printf("Backup will be skipped. Make sure you have credentials.json and completed OAuth setup.")
+		log.Printf("Backup will be skipped. Make sure you have %s and completed OAuth setup.", a.dirs.Credentials())
 	} else {
 		log.Println("Backup service initialized successfully")
 		a.backup = backupService
 	}
 }
 
-func (a *App) TrackJobApp(jobAppData string, platform string) (*models.JobApplication, error) {
+// withStore calls fn with the active profile's database, which stays open
+// until fn returns. fn must not call other App methods that take a.mu.
+func (a *App) withStore(fn func(store *database.Store) error) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return fn(a.store)
+}
+
+// openProfile opens and migrates a profile's database, purging applications
+// that have been in the trash longer than the retention period
+func (a *App) openProfile(p *profile.Profile) (*database.Store, error) {
+	path := a.dirs.ProfileDatabase(p.Name)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("unable to create profile folder: %v", err)
+	}
+	store, err := database.NewStore(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.config.TrashRetentionDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -a.config.TrashRetentionDays)
+		purged, err := store.PurgeDeletedApps(cutoff)
+		if err != nil {
+			log.Printf("Warning: Failed to purge trash: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d applications deleted more than %d days ago", purged, a.config.TrashRetentionDays)
+		}
+	}
+	return store, nil
+}
+
+// GetProfiles returns the profiles and the one in use
+func (a *App) GetProfiles() *profile.Profiles {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	profiles := *a.profiles
+	profiles.Profiles = slices.Clone(a.profiles.Profiles)
+	return &profiles
+}
+
+// SaveProfile adds a profile, or changes the Drive backup of an existing one
+func (a *App) SaveProfile(p profile.Profile) (*profile.Profiles, error) {
+	a.mu.Lock()
+	var saved profile.Profile
+	put, err := a.profiles.Put(p)
+	if err == nil {
+		saved = *put
+		err = a.profiles.Save()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		fmt.Printf("Error saving profile: %v\n", err)
+		return nil, err
+	}
+
+	fmt.Printf("Saved profile %q backing up to %s\n", saved.Name, saved.BackupName)
+	return a.GetProfiles(), nil
+}
+
+// DeleteProfile removes a profile from the list. Its database is left on
+// disk and is used again if a profile with the same name is added.
+func (a *App) DeleteProfile(name string) (*profile.Profiles, error) {
+	a.mu.Lock()
+	err := a.profiles.Remove(name)
+	if err == nil {
+		err = a.profiles.Save()
+	}
+	a.mu.Unlock()
+	if err != nil {
+		fmt.Printf("Error deleting profile: %v\n", err)
+		return nil, err
+	}
+
+	fmt.Printf("Deleted profile %q, its database stays in %s\n", name, filepath.Dir(a.dirs.ProfileDatabase(name)))
+	return a.GetProfiles(), nil
+}
+
+// SwitchProfile backs up the profile in use, then opens another profile's
+// database in its place and remembers it for the next launch
+func (a *App) SwitchProfile(name string) (*profile.Profiles, error) {
+	a.switchMu.Lock()
+	defer a.switchMu.Unlock()
+
+	a.mu.RLock()
+	next := a.profiles.Get(name)
+	var target profile.Profile
+	if next != nil {
+		target = *next
+	}
+	current := a.profiles.Active
+	a.mu.RUnlock()
+	if next == nil {
+		err := fmt.Errorf("no profile named %q", name)
+		fmt.Printf("Error switching profile: %v\n", err)
+		return nil, err
+	}
+	if target.Name == current {
+		return a.GetProfiles(), nil
+	}
+
+	if a.backup != nil {
+		if err := a.backupActiveProfile(); err != nil {
+			log.Printf("Error backing up database: %v", err)
+		}
+	}
+	store, err := a.openProfile(&target)
+	if err != nil {
+		fmt.Printf("Error switching profile: %v\n", err)
+		return nil, err
+	}
+
+	// Taking the write lock waits for calls still using the previous store
+	a.mu.Lock()
+	previous := a.store
+	a.store = store
+	a.profiles.Active = target.Name
+	err = a.profiles.Save()
+	a.mu.Unlock()
+	if err != nil {
+		fmt.Printf("Error remembering profile: %v\n", err)
+	}
+	if err := previous.Close(); err != nil {
+		fmt.Printf("Error closing database: %v\n", err)
+	}
+
+	fmt.Printf("Switched from profile %q to %q\n", current, target.Name)
+	return a.GetProfiles(), nil
+}
+
+// backupActiveProfile uploads the database in use to its profile's Drive
+// backup, when backups are set up
+func (a *App) backupActiveProfile() error {
+	if a.backup == nil {
+		return fmt.Errorf("backup service not initialized")
+	}
+	a.mu.RLock()
+	path := a.store.Path()
+	backupName := a.profiles.Get(a.profiles.Active).BackupName
+	a.mu.RUnlock()
+
+	log.Printf("Backing up %s to %s...", path, backupName)
+	return a.backup.BackupDatabase(path, backupName)
+}
+
+// TrackJobApp parses copied job posting data. When platform is empty or "auto"
+// the parser is detected from the mf-URL line or page markers. The result
+// carries per-field confidence and warnings for the review screen.
+func (a *App) TrackJobApp(jobAppData string, platform string) (*parser.ParseResult, error) {
 	fmt.Printf("Received job app data from %s: %s\n", platform, jobAppData)
 
-	lines := strings.Split(jobAppData, "\n")
-	jobApp := &models.JobApplication{}
+	p, err := parser.Select(platform, jobAppData)
+	if err != nil {
+		fmt.Printf("Error selecting parser: %v\n", err)
+		return nil, err
+	}
+
+	result, err := parser.Run(p, jobAppData)
+	if err != nil {
+		fmt.Printf("Error parsing job app with %s: %v\n", p.Name(), err)
+		return nil, err
+	}
+
+	fmt.Printf("Parsed job app with %s, warnings: %v\n", result.Parser, result.Warnings)
+	return result, nil
+}
 
-	// Platform-specific parsing logic
-	switch platform {
-	case "linkedin":
-		return parser.ParseLinkedInJob(lines, jobApp)
-	case "greenhouse":
-		return parser.ParseGreenhouseJob(lines, jobApp)
-	default:
-		return parser.ParseLinkedInJob(lines, jobApp) // Default to LinkedIn parsing
+// SaveJobApp saves a job application unless it looks like one already saved,
+// in which case nothing is saved and the possible duplicates are returned so
+// the user can merge, skip or save anyway
+func (a *App) SaveJobApp(jobApp *models.JobApplication) ([]duplicate.Candidate, error) {
+	candidates, err := a.FindDuplicates(jobApp)
+	if err != nil {
+		return nil, err
 	}
+	if len(candidates) > 0 {
+		fmt.Printf("Not saving %s at %s: %d possible duplicates\n", jobApp.Position, jobApp.Company, len(candidates))
+		return candidates, nil
+	}
+
+	return nil, a.SaveJobAppAnyway(jobApp)
 }
 
-func (a *App) SaveJobApp(jobApp *models.JobApplication) error {
-	if err := database.CreateApp(jobApp); err != nil {
+// SaveJobAppAnyway saves a job application without checking for duplicates
+func (a *App) SaveJobAppAnyway(jobApp *models.JobApplication) error {
+	if jobApp.Status == "" {
+		jobApp.Status = models.SUBMITTED
+	}
+	if err := a.validateJobApp(jobApp); err != nil {
 		fmt.Printf("Error saving job app: %v\n", err)
 		return err
 	}
 
-	fmt.Printf("Saved job app: %s at %s (ID: %d)\n", jobApp.Position, jobApp.Company, jobApp.AppId)
+	return a.withStore(func(store *database.Store) error {
+		if err := store.CreateApp(jobApp); err != nil {
+			fmt.Printf("Error saving job app: %v\n", err)
+			return err
+		}
+
+		// The timeline starts with the status the application was saved with
+		event := &models.StatusEvent{AppId: jobApp.AppId, ToStatus: jobApp.Status, ChangedAt: time.Now(), Note: "Saved"}
+		if err := store.CreateStatusEvent(event); err != nil {
+			fmt.Printf("Error recording initial status: %v\n", err)
+		}
+		a.recordOperation(store, models.CREATE, nil, jobApp, fmt.Sprintf("Saved %s at %s", jobApp.Position, jobApp.Company))
+
+		fmt.Printf("Saved job app: %s at %s (ID: %d)\n", jobApp.Position, jobApp.Company, jobApp.AppId)
+		return nil
+	})
+}
+
+// FindDuplicates returns saved applications that look like the same job,
+// matched by board job ID, canonical URL or company and title
+func (a *App) FindDuplicates(jobApp *models.JobApplication) ([]duplicate.Candidate, error) {
+	var saved []models.JobApplication
+	err := a.withStore(func(store *database.Store) (err error) {
+		saved, err = store.ListApps()
+		return err
+	})
+	if err != nil {
+		fmt.Printf("Error listing job apps: %v\n", err)
+		return nil, err
+	}
+	return duplicate.Find(jobApp, saved), nil
+}
+
+// MergeJobApp fills the empty fields of a saved application with the newly
+// parsed one instead of saving it again
+func (a *App) MergeJobApp(existingId uint, jobApp *models.JobApplication) (*models.JobApplication, error) {
+	var existing *models.JobApplication
+	err := a.withStore(func(store *database.Store) (err error) {
+		existing, err = store.GetAppByID(existingId)
+		if err != nil {
+			fmt.Printf("Error getting job app %d: %v\n", existingId, err)
+			return err
+		}
+
+		before := *existing
+		duplicate.Merge(existing, jobApp)
+		if err := store.UpdateApp(existing); err != nil {
+			fmt.Printf("Error merging job app: %v\n", err)
+			return err
+		}
+		a.recordOperation(store, models.UPDATE, &before, existing, fmt.Sprintf("Merged into %s at %s", existing.Position, existing.Company))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Merged job app into %s at %s (ID: %d)\n", existing.Position, existing.Company, existing.AppId)
+	return existing, nil
+}
+
+// GetJobApp returns one job application with its annualized salary
+func (a *App) GetJobApp(appId uint) (*models.JobApplication, error) {
+	var app *models.JobApplication
+	err := a.withStore(func(store *database.Store) (err error) {
+		app, err = store.GetAppByID(appId)
+		return err
+	})
+	if err != nil {
+		fmt.Printf("Error getting job app %d: %v\n", appId, err)
+		return nil, err
+	}
+	apps := []models.JobApplication{*app}
+	salary.AnnualizeAll(apps, a.config)
+	return &apps[0], nil
+}
+
+// UpdateJobApp saves edits to a job application. Company and position are
+// required, status changes must be allowed by the pipeline and are recorded
+// in the timeline, and an edited salary text is parsed again.
+func (a *App) UpdateJobApp(jobApp *models.JobApplication) (*models.JobApplication, error) {
+	if err := a.validateJobApp(jobApp); err != nil {
+		fmt.Printf("Error updating job app %d: %v\n", jobApp.AppId, err)
+		return nil, err
+	}
+
+	err := a.withStore(func(store *database.Store) error {
+		existing, err := store.GetAppByID(jobApp.AppId)
+		if err != nil {
+			fmt.Printf("Error getting job app %d: %v\n", jobApp.AppId, err)
+			return err
+		}
+		if err := a.pipeline.Validate(existing.Status, jobApp.Status); err != nil {
+			fmt.Printf("Error updating job app %d: %v\n", jobApp.AppId, err)
+			return err
+		}
+		if jobApp.SalaryRange != existing.SalaryRange {
+			parser.ReparseSalary(jobApp)
+		}
+
+		if err := store.UpdateApp(jobApp); err != nil {
+			fmt.Printf("Error updating job app %d: %v\n", jobApp.AppId, err)
+			return err
+		}
+
+		if jobApp.Status != existing.Status {
+			event := &models.StatusEvent{AppId: jobApp.AppId, FromStatus: existing.Status, ToStatus: jobApp.Status, ChangedAt: time.Now(), Note: "Edited"}
+			if err := store.CreateStatusEvent(event); err != nil {
+				fmt.Printf("Error recording status change: %v\n", err)
+			}
+		}
+		a.recordOperation(store, models.UPDATE, existing, jobApp, fmt.Sprintf("Edited %s at %s", jobApp.Position, jobApp.Company))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Updated job app: %s at %s (ID: %d)\n", jobApp.Position, jobApp.Company, jobApp.AppId)
+	return a.GetJobApp(jobApp.AppId)
+}
+
+// DeleteJobApp moves a job application to the trash
+func (a *App) DeleteJobApp(appId uint) error {
+	err := a.withStore(func(store *database.Store) error {
+		app, err := store.GetAppByID(appId)
+		if err != nil {
+			fmt.Printf("Error getting job app %d: %v\n", appId, err)
+			return err
+		}
+		if err := store.DeleteApp(appId); err != nil {
+			fmt.Printf("Error deleting job app %d: %v\n", appId, err)
+			return err
+		}
+		a.recordOperation(store, models.DELETE, nil, app, fmt.Sprintf("Deleted %s at %s", app.Position, app.Company))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Moved job app %d to the trash\n", appId)
 	return nil
 }
 
+// GetTrash returns the deleted job applications, most recently deleted first
+func (a *App) GetTrash() ([]models.JobApplication, error) {
+	var apps []models.JobApplication
+	err := a.withStore(func(store *database.Store) (err error) {
+		apps, err = store.GetTrash()
+		return err
+	})
+	if err != nil {
+		fmt.Printf("Error getting trash: %v\n", err)
+		return nil, err
+	}
+	salary.AnnualizeAll(apps, a.config)
+	return apps, nil
+}
+
+// RestoreJobApp takes a job application out of the trash
+func (a *App) RestoreJobApp(appId uint) (*models.JobApplication, error) {
+	var app *models.JobApplication
+	err := a.withStore(func(store *database.Store) (err error) {
+		if err := store.RestoreApp(appId); err != nil {
+			fmt.Printf("Error restoring job app %d: %v\n", appId, err)
+			return err
+		}
+
+		app, err = store.GetAppByID(appId)
+		if err != nil {
+			fmt.Printf("Error getting job app %d: %v\n", appId, err)
+			return err
+		}
+		a.recordOperation(store, models.RESTORE, nil, app, fmt.Sprintf("Restored %s at %s", app.Position, app.Company))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apps := []models.JobApplication{*app}
+	salary.AnnualizeAll(apps, a.config)
+	fmt.Printf("Restored job app %d\n", appId)
+	return &apps[0], nil
+}
+
+// PurgeJobApp permanently deletes a job application in the trash
+func (a *App) PurgeJobApp(appId uint) error {
+	err := a.withStore(func(store *database.Store) error {
+		return store.PurgeApp(appId)
+	})
+	if err != nil {
+		fmt.Printf("Error purging job app %d: %v\n", appId, err)
+		return err
+	}
+
+	fmt.Printf("Purged job app %d\n", appId)
+	return nil
+}
+
+// EmptyTrash permanently deletes every job application in the trash
+func (a *App) EmptyTrash() (int64, error) {
+	var purged int64
+	err := a.withStore(func(store *database.Store) (err error) {
+		purged, err = store.PurgeDeletedApps(time.Now())
+		return err
+	})
+	if err != nil {
+		fmt.Printf("Error emptying trash: %v\n", err)
+		return 0, err
+	}
+
+	fmt.Printf("Purged %d job apps from the trash\n", purged)
+	return purged, nil
+}
+
+// validateJobApp checks the fields every saved application needs
+func (a *App) validateJobApp(jobApp *models.JobApplication) error {
+	jobApp.Company = strings.TrimSpace(jobApp.Company)
+	jobApp.Position = strings.TrimSpace(jobApp.Position)
+	if jobApp.Company == "" {
+		return fmt.Errorf("company is required")
+	}
+	if jobApp.Position == "" {
+		return fmt.Errorf("position is required")
+	}
+	if _, ok := a.pipeline.Lookup(jobApp.Status); !ok {
+		return fmt.Errorf("unknown status %s", jobApp.Status)
+	}
+	return nil
+}
+
+// UpdateJobAppStatus moves an application to a new status and records the
+// change, with an optional note, in its timeline. Moves the pipeline does
+// not allow, such as leaving a final status, are rejected.
+func (a *App) UpdateJobAppStatus(appId uint, status models.Status, note string) (*models.StatusEvent, error) {
+	var event *models.StatusEvent
+	err := a.withStore(func(store *database.Store) error {
+		app, err := store.GetAppByID(appId)
+		if err != nil {
+			fmt.Printf("Error getting job app %d: %v\n", appId, err)
+			return err
+		}
+		if err := a.pipeline.Validate(app.Status, status); err != nil {
+			fmt.Printf("Error updating status of job app %d: %v\n", appId, err)
+			return err
+		}
+
+		event, err = store.UpdateAppStatus(appId, status, note)
+		if err != nil {
+			fmt.Printf("Error updating status of job app %d: %v\n", appId, err)
+			return err
+		}
+		if event != nil {
+			moved := *app
+			moved.Status = status
+			a.recordOperation(store, models.UPDATE, app, &moved, fmt.Sprintf("Moved %s at %s to %s", app.Position, app.Company, status))
+			fmt.Printf("Job app %d moved from %s to %s\n", appId, event.FromStatus, event.ToStatus)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Undo reverts the most recent change made through the app and returns it,
+// or nil when there is nothing to undo
+func (a *App) Undo() (*models.Operation, error) {
+	var op *models.Operation
+	err := a.withStore(func(store *database.Store) (err error) {
+		op, err = store.UndoOperation()
+		return err
+	})
+	if err != nil {
+		fmt.Printf("Error undoing: %v\n", err)
+		return nil, err
+	}
+	if op != nil {
+		fmt.Printf("Undid: %s\n", op.Summary)
+	}
+	return op, nil
+}
+
+// Redo applies the change undone last again and returns it, or nil when
+// there is nothing to redo
+func (a *App) Redo() (*models.Operation, error) {
+	var op *models.Operation
+	err := a.withStore(func(store *database.Store) (err error) {
+		op, err = store.RedoOperation()
+		return err
+	})
+	if err != nil {
+		fmt.Printf("Error redoing: %v\n", err)
+		return nil, err
+	}
+	if op != nil {
+		fmt.Printf("Redid: %s\n", op.Summary)
+	}
+	return op, nil
+}
+
+// recordOperation logs a change for Undo in the store it was made in. A
+// change that cannot be logged is still kept, it just cannot be undone.
+func (a *App) recordOperation(store *database.Store, kind models.OperationKind, before *models.JobApplication, after *models.JobApplication, summary string) {
+	if err := store.RecordOperation(kind, before, after, summary, a.config.UndoDepth); err != nil {
+		fmt.Printf("Error recording operation: %v\n", err)
+	}
+}
+
+// GetStatusPipeline returns the status stages in display order, each with
+// the stages it can move to
+func (a *App) GetStatusPipeline() []pipeline.Stage {
+	return a.pipeline.Stages()
+}
+
+// GetStatusTimeline returns the status changes of an application, oldest first
+func (a *App) GetStatusTimeline(appId uint) ([]models.StatusEvent, error) {
+	var events []models.StatusEvent
+	err := a.withStore(func(store *database.Store) (err error) {
+		events, err = store.GetStatusEvents(appId)
+		return err
+	})
+	if err != nil {
+		fmt.Printf("Error getting status timeline of job app %d: %v\n", appId, err)
+		return nil, err
+	}
+	return events, nil
+}
+
 // GetAllJobApps returns all job applications from the database
 func (a *App) GetAllJobApps() ([]models.JobApplication, error) {
-	apps, err := database.GetAllApps()
+	var apps []models.JobApplication
+	err := a.withStore(func(store *database.Store) (err error) {
+		apps, err = store.GetAllApps()
+		return err
+	})
 	if err != nil {
 		fmt.Printf("Error getting job apps: %v\n", err)
 		return nil, err
 	}
 
+	salary.AnnualizeAll(apps, a.config)
 	fmt.Printf("Retrieved %d job applications\n", len(apps))
 	return apps, nil
 }
 
+// QueryJobApps returns one page of the job applications matching the
+// query's filters in its sort order, with the total number of matches
+func (a *App) QueryJobApps(query database.Query) (*database.Page, error) {
+	query.AnnualSalarySQL = salary.AnnualSQL(a.config)
+	var page *database.Page
+	err := a.withStore(func(store *database.Store) (err error) {
+		page, err = store.QueryApps(query)
+		return err
+	})
+	if err != nil {
+		fmt.Printf("Error querying job apps: %v\n", err)
+		return nil, err
+	}
+
+	salary.AnnualizeAll(page.Apps, a.config)
+	fmt.Printf("Query matched %d job applications, returning %d from %d\n", page.Total, len(page.Apps), page.Offset)
+	return page, nil
+}
+
+// GetSavedSearches returns the saved searches, pinned ones first, each with
+// the number of applications it currently matches
+func (a *App) GetSavedSearches() ([]database.SavedSearch, error) {
+	var searches []database.SavedSearch
+	err := a.withStore(func(store *database.Store) (err error) {
+		searches, err = store.ListSavedSearches()
+		if err != nil {
+			fmt.Printf("Error getting saved searches: %v\n", err)
+			return err
+		}
+
+		annualSalarySQL := salary.AnnualSQL(a.config)
+		for i := range searches {
+			query := searches[i].Query
+			query.AnnualSalarySQL = annualSalarySQL
+			searches[i].Count, err = store.CountApps(query)
+			if err != nil {
+				fmt.Printf("Error counting saved search %q: %v\n", searches[i].Name, err)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return searches, nil
+}
+
+// SaveSearch creates a saved search, or updates the one with the same ID
+func (a *App) SaveSearch(search *database.SavedSearch) (*database.SavedSearch, error) {
+	// Pages are chosen when the search runs, not saved with it
+	search.Query.Limit, search.Query.Offset = 0, 0
+	err := a.withStore(func(store *database.Store) error {
+		return store.SaveSearch(search)
+	})
+	if err != nil {
+		fmt.Printf("Error saving search: %v\n", err)
+		return nil, err
+	}
+	fmt.Printf("Saved search #%d %q\n", search.Id, search.Name)
+	return search, nil
+}
+
+// DeleteSavedSearch deletes a saved search
+func (a *App) DeleteSavedSearch(id uint) error {
+	err := a.withStore(func(store *database.Store) error {
+		return store.DeleteSavedSearch(id)
+	})
+	if err != nil {
+		fmt.Printf("Error deleting saved search: %v\n", err)
+		return err
+	}
+	fmt.Printf("Deleted saved search #%d\n", id)
+	return nil
+}
+
 func (a *App) SearchByCompany(companyName string) ([]models.JobApplication, error) {
-	apps, err := database.SearchByCompany(companyName)
+	var apps []models.JobApplication
+	err := a.withStore(func(store *database.Store) (err error) {
+		apps, err = store.SearchByCompany(companyName)
+		return err
+	})
 	if err != nil {
 		fmt.Printf("Error searching by company: %v\n", err)
 		return nil, err
 	}
+	salary.AnnualizeAll(apps, a.config)
 	return apps, nil
 }
 
+// SearchJobApps runs a full-text search over company, position, notes and
+// description using FTS5 query syntax, best match first. Each result carries
+// a snippet with the matches marked by database.SnippetStart and SnippetEnd.
+func (a *App) SearchJobApps(query string) ([]database.SearchResult, error) {
+	if strings.TrimSpace(query) == "" {
+		return []database.SearchResult{}, nil
+	}
+
+	var results []database.SearchResult
+	err := a.withStore(func(store *database.Store) (err error) {
+		results, err = store.SearchApps(query)
+		return err
+	})
+	if err != nil {
+		fmt.Printf("Error searching job apps for %q: %v\n", query, err)
+		return nil, err
+	}
+	for i := range results {
+		if annualized, err := salary.Annualize(&results[i].JobApplication, a.config); err == nil {
+			results[i].AnnualizedSalary = annualized
+			results[i].AnnualizedCurrency = a.config.HomeCurrency
+		}
+	}
+
+	fmt.Printf("Found %d job applications for %q\n", len(results), query)
+	return results, nil
+}
+
 // Helper function to safely get string from map
 func getStringFromMap(m map[string]interface{}, key string) string {
 	if val, ok := m[key]; ok {
@@ -103,24 +760,29 @@ func getStringFromMap(m map[string]interface{}, key string) string {
 }
 
 func (a *App) BeforeClose(ctx context.Context) bool {
+	a.switchMu.Lock()
+	defer a.switchMu.Unlock()
+
 	if a.backup != nil {
 		log.Println("Backing up database before closing...")
-		if err := a.backup.BackupDatabase("job_apps.db"); err != nil {
+		if err := a.backupActiveProfile(); err != nil {
 			log.Printf("Error backing up database: %v", err)
 		}
 	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.store != nil {
+		if err := a.store.Close(); err != nil {
+			log.Printf("Error closing database: %v", err)
+		}
+	}
 	return false
 }
 
 // TestBackup manually triggers a backup (for testing)
 func (a *App) TestBackup() error {
-	if a.backup == nil {
-		return fmt.Errorf("backup service not initialized")
-	}
-
 	log.Println("Testing backup...")
-	err := a.backup.BackupDatabase("job_apps.db")
-	if err != nil {
+	if err := a.backupActiveProfile(); err != nil {
 		log.Printf("Backup test failed: %v", err)
 		return err
 	}
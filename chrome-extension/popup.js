@@ -4,7 +4,11 @@ document.getElementById('exportBtn').addEventListener('click', async () => {
     const results = await chrome.scripting.executeScript({
         target: { tabId: tab.id },
         func: () => {
-            return document.body.innerHTML;
+            // JSON-LD JobPosting data often lives in <head>, outside body.innerHTML
+            const jsonLD = Array.from(document.head.querySelectorAll('script[type="application/ld+json"]'))
+                .map((script) => script.outerHTML)
+                .join('');
+            return document.body.innerHTML + jsonLD;
         }
     });
 
@@ -1,16 +1,60 @@
+import { useState, useEffect } from 'react'
 import { Link, Routes, Route } from 'react-router-dom'
 import TrackJob from './TrackJob'
 import Search from './search'
+import Trash from './Trash'
+import ProfileSwitcher from './ProfileSwitcher'
 import './App.css'
 
 function App() {
+    const [lastChange, setLastChange] = useState('')
+
+    // Ctrl+Z / Ctrl+Shift+Z (or Ctrl+Y) undo and redo saved changes; text
+    // fields keep their own undo
+    useEffect(() => {
+        const handleKeyDown = async (e) => {
+            if (!(e.ctrlKey || e.metaKey) || e.target.closest('input, textarea, select')) {
+                return
+            }
+            const key = e.key.toLowerCase()
+            const redo = key === 'y' || (key === 'z' && e.shiftKey)
+            if (key !== 'z' && !redo) {
+                return
+            }
+            e.preventDefault()
+            try {
+                const op = redo ? await window.go.main.App.Redo() : await window.go.main.App.Undo()
+                if (!op) {
+                    setLastChange(redo ? 'Nothing to redo' : 'Nothing to undo')
+                    return
+                }
+                setLastChange(`${redo ? 'Redid' : 'Undid'}: ${op.summary}`)
+                window.dispatchEvent(new Event('jobapps-changed'))
+            } catch (error) {
+                console.error("Error undoing:", error)
+                setLastChange(String(error))
+            }
+        }
+        window.addEventListener('keydown', handleKeyDown)
+        return () => window.removeEventListener('keydown', handleKeyDown)
+    }, [])
+
+    // Every view reloads from the new profile's database
+    const handleProfileSwitched = (name) => {
+        setLastChange(`Switched to profile ${name}`)
+        window.dispatchEvent(new CustomEvent('jobapps-changed', { detail: { profile: name } }))
+    }
+
     return (
         <div className="app-container">
             <div>
                 <nav>
                     <Link to="/">Track Job</Link>
                     <Link to="/search">Search</Link>
+                    <Link to="/trash">Trash</Link>
+                    <ProfileSwitcher onSwitched={handleProfileSwitched} />
                 </nav>
+                {lastChange && <p className="last-change">{lastChange}</p>}
             </div>
 
 
@@ -18,6 +62,7 @@ function App() {
                 <Routes>
                     <Route path="/" index element={<TrackJob />} />
                     <Route path="/search" element={<Search />} />
+                    <Route path="/trash" element={<Trash />} />
                 </Routes>
             </div>
 
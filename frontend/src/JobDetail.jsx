@@ -0,0 +1,139 @@
+import { useState, useEffect } from 'react'
+
+const editableFields = [
+  ['company', 'Company'],
+  ['position', 'Position'],
+  ['location', 'Location'],
+  ['salaryRange', 'Salary Range'],
+  ['workplaceType', 'Workplace Type'],
+  ['employmentType', 'Employment Type'],
+  ['department', 'Department'],
+  ['dateApplied', 'Date Applied'],
+  ['website', 'Website'],
+]
+
+function JobDetail({ appId, stages, onSaved, onDeleted, onClose }) {
+  const [job, setJob] = useState(null)
+  const [isSaving, setIsSaving] = useState(false)
+  const [error, setError] = useState('')
+  const [tagsText, setTagsText] = useState('')
+
+  useEffect(() => {
+    window.go.main.App.GetJobApp(appId)
+      .then((job) => {
+        setJob(job)
+        setTagsText((job.tags || []).join(', '))
+      })
+      .catch((error) => setError(String(error)))
+  }, [appId])
+
+  if (!job) {
+    return <div className="job-detail">{error || 'Loading...'}</div>
+  }
+
+  const stage = stages.find((candidate) => candidate.name === job.status)
+  const statusOptions = [job.status, ...(stage?.next || [])]
+  const stageLabel = (name) => stages.find((candidate) => candidate.name === name)?.label || name
+
+  const handleChange = (field, value) => {
+    setJob((current) => ({ ...current, [field]: value }))
+  }
+
+  const handleSave = async () => {
+    setIsSaving(true)
+    setError('')
+    try {
+      const tags = tagsText.split(',').map((tag) => tag.trim()).filter(Boolean)
+      const updated = await window.go.main.App.UpdateJobApp({ ...job, tags, dateApplied: job.dateApplied || null })
+      setJob(updated)
+      setTagsText((updated.tags || []).join(', '))
+      onSaved(updated)
+    } catch (error) {
+      console.error("Error updating job:", error)
+      setError(String(error))
+    } finally {
+      setIsSaving(false)
+    }
+  }
+
+  const handleDelete = async () => {
+    if (!window.confirm(`Move ${job.position} at ${job.company} to the trash?`)) {
+      return
+    }
+    setIsSaving(true)
+    try {
+      await window.go.main.App.DeleteJobApp(job.appId)
+      onDeleted(job.appId)
+    } catch (error) {
+      console.error("Error deleting job:", error)
+      setError(String(error))
+      setIsSaving(false)
+    }
+  }
+
+  return (
+    <div className="job-detail">
+      {editableFields.map(([field, label]) => (
+        <label key={field} className="job-detail-field">
+          <span>{label}</span>
+          <input
+            type={field === 'dateApplied' ? 'date' : 'text'}
+            value={job[field] || ''}
+            onChange={(e) => handleChange(field, e.target.value)}
+            className="search-input"
+            disabled={isSaving}
+          />
+        </label>
+      ))}
+      <label className="job-detail-field">
+        <span>Status</span>
+        <select
+          value={job.status}
+          onChange={(e) => handleChange('status', e.target.value)}
+          className="search-select"
+          disabled={isSaving}
+        >
+          {statusOptions.map((status) => (
+            <option key={status} value={status}>{stageLabel(status)}</option>
+          ))}
+        </select>
+      </label>
+      <label className="job-detail-field">
+        <span>Tags</span>
+        <input
+          type="text"
+          value={tagsText}
+          onChange={(e) => setTagsText(e.target.value)}
+          placeholder="golang, remote, referral"
+          className="search-input"
+          disabled={isSaving}
+        />
+      </label>
+      <label className="job-detail-field">
+        <span>Notes</span>
+        <textarea
+          value={job.notes || ''}
+          onChange={(e) => handleChange('notes', e.target.value)}
+          className="search-input"
+          disabled={isSaving}
+        />
+      </label>
+
+      {error && <p className="job-detail-error">{error}</p>}
+
+      <div className="job-detail-actions">
+        <button onClick={handleSave} disabled={isSaving} className="search-button">
+          {isSaving ? 'Saving...' : 'Save changes'}
+        </button>
+        <button onClick={onClose} disabled={isSaving} className="search-button">
+          Close
+        </button>
+        <button onClick={handleDelete} disabled={isSaving} className="search-button job-detail-delete">
+          Delete
+        </button>
+      </div>
+    </div>
+  )
+}
+
+export default JobDetail
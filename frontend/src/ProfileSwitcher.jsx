@@ -0,0 +1,80 @@
+import { useState, useEffect } from 'react'
+
+const NewProfile = '__new'
+const RemoveProfile = '__remove'
+
+// ProfileSwitcher picks the job search (profile) in use. Each profile has its
+// own database and Drive backup; onSwitched is called with its name.
+function ProfileSwitcher({ onSwitched }) {
+    const [profiles, setProfiles] = useState({ active: '', profiles: [] })
+
+    useEffect(() => {
+        window.go.main.App.GetProfiles().then(setProfiles)
+    }, [])
+
+    const switchTo = async (name) => {
+        try {
+            setProfiles(await window.go.main.App.SwitchProfile(name))
+            onSwitched(name)
+        } catch (error) {
+            console.error("Error switching profile:", error)
+            alert("Error switching profile: " + error)
+        }
+    }
+
+    const handleNew = async () => {
+        const name = window.prompt('Name for the new profile, e.g. "2024 search":')
+        if (!name?.trim()) {
+            return
+        }
+        try {
+            await window.go.main.App.SaveProfile({ name: name.trim(), backupName: '' })
+            await switchTo(name.trim())
+        } catch (error) {
+            console.error("Error creating profile:", error)
+            alert("Error creating profile: " + error)
+        }
+    }
+
+    const handleRemove = async () => {
+        const name = window.prompt(`Profile to remove from the list (its database is kept on disk):`)
+        if (!name?.trim()) {
+            return
+        }
+        try {
+            setProfiles(await window.go.main.App.DeleteProfile(name.trim()))
+        } catch (error) {
+            console.error("Error removing profile:", error)
+            alert("Error removing profile: " + error)
+        }
+    }
+
+    const handleChange = (value) => {
+        if (value === NewProfile) {
+            handleNew()
+        } else if (value === RemoveProfile) {
+            handleRemove()
+        } else {
+            switchTo(value)
+        }
+    }
+
+    return (
+        <select
+            value={profiles.active}
+            onChange={(e) => handleChange(e.target.value)}
+            className="profile-select"
+            title="Profile"
+        >
+            {profiles.profiles.map((profile) => (
+                <option key={profile.name} value={profile.name} title={`Backs up to ${profile.backupName}`}>
+                    {profile.name}
+                </option>
+            ))}
+            <option value={NewProfile}>New profile…</option>
+            {profiles.profiles.length > 1 && <option value={RemoveProfile}>Remove a profile…</option>}
+        </select>
+    )
+}
+
+export default ProfileSwitcher
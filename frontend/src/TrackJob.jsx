@@ -1,6 +1,23 @@
-import { useState } from 'react'
+import { useState, useEffect } from 'react'
 import './TrackJob.css'
 
+const platformLabels = {
+  linkedin: 'LinkedIn',
+  greenhouse: 'Greenhouse',
+  lever: 'Lever',
+  workday: 'Workday',
+  ashby: 'Ashby',
+  jsonld: 'Other (schema.org JobPosting)',
+}
+
+const formatSalary = (job) => {
+  if (!job.salaryMin && !job.salaryMax) return null
+  const amounts = job.salaryMin === job.salaryMax
+    ? job.salaryMin.toLocaleString()
+    : `${job.salaryMin.toLocaleString()} – ${job.salaryMax.toLocaleString()}`
+  return `${amounts} ${job.salaryCurrency}${job.salaryPeriod ? ` / ${job.salaryPeriod.toLowerCase()}` : ''}`
+}
+
 function TrackJob() {
   const [jobText, setJobText] = useState('')
   const [isLoading, setIsLoading] = useState(false)
@@ -9,7 +26,16 @@ function TrackJob() {
   const [isSaving, setIsSaving] = useState(false)
   const [isEditing, setIsEditing] = useState(false)
   const [editedJob, setEditedJob] = useState(null)
-  const [platform, setPlatform] = useState('linkedin')
+  const [platform, setPlatform] = useState('auto')
+  const [parseInfo, setParseInfo] = useState(null)
+  const [duplicates, setDuplicates] = useState([])
+  const [stages, setStages] = useState([])
+
+  useEffect(() => {
+    window.go?.main?.App?.GetStatusPipeline().then(setStages).catch((error) => {
+      console.error("Error loading statuses:", error)
+    })
+  }, [])
 
   const handleTrackJob = async () => {
     console.log("Button clicked!")
@@ -27,8 +53,10 @@ function TrackJob() {
         throw new Error("Wails runtime not available. Make sure you're running through the Wails app, not the browser.")
       }
 
-      const jobApp = await window.go.main.App.TrackJobApp(jobText.trim(), platform)
-      console.log("Parsed job:", jobApp)
+      const result = await window.go.main.App.TrackJobApp(jobText.trim(), platform)
+      console.log("Parse result:", result)
+      const jobApp = result.jobApp
+      setParseInfo(result)
       setParsedJob(jobApp)
       setEditedJob(jobApp) // Initialize edited job with parsed data
       setJobText('')
@@ -42,17 +70,42 @@ function TrackJob() {
     }
   }
 
+  const resetForm = () => {
+    setParsedJob(null)
+    setEditedJob(null)
+    setParseInfo(null)
+    setDuplicates([])
+    setNotes('')
+    setIsEditing(false)
+  }
+
   const handleSaveJob = async () => {
     setIsSaving(true)
     try {
       // Use edited job data if available, otherwise use parsed job
       const jobToSave = { ...(editedJob || parsedJob), notes: notes.trim() }
-      await window.go.main.App.SaveJobApp(jobToSave)
+      const candidates = await window.go.main.App.SaveJobApp(jobToSave)
+      if (candidates && candidates.length > 0) {
+        setDuplicates(candidates)
+        return
+      }
+      alert("Job application saved successfully!")
+      resetForm()
+    } catch (error) {
+      console.error("Error saving job:", error)
+      alert("Error saving job: " + error.message)
+    } finally {
+      setIsSaving(false)
+    }
+  }
+
+  const handleSaveAnyway = async () => {
+    setIsSaving(true)
+    try {
+      const jobToSave = { ...(editedJob || parsedJob), notes: notes.trim() }
+      await window.go.main.App.SaveJobAppAnyway(jobToSave)
       alert("Job application saved successfully!")
-      setParsedJob(null)
-      setEditedJob(null)
-      setNotes('')
-      setIsEditing(false)
+      resetForm()
     } catch (error) {
       console.error("Error saving job:", error)
       alert("Error saving job: " + error.message)
@@ -61,6 +114,22 @@ function TrackJob() {
     }
   }
 
+  const handleMerge = async (existingId) => {
+    setIsSaving(true)
+    try {
+      const jobToSave = { ...(editedJob || parsedJob), notes: notes.trim() }
+      const merged = await window.go.main.App.MergeJobApp(existingId, jobToSave)
+      alert(`Merged into ${merged.position} at ${merged.company}`)
+      resetForm()
+    } catch (error) {
+      console.error("Error merging job:", error)
+      alert("Error merging job: " + error.message)
+    } finally {
+      setIsSaving(false)
+    }
+  }
+
+
   const handleEditClick = () => {
     setIsEditing(true)
     if (!editedJob) {
@@ -73,6 +142,13 @@ function TrackJob() {
     setEditedJob({ ...parsedJob }) // Reset to original parsed data
   }
 
+  // Highlight fields the parser was unsure about so they get double-checked
+  const reviewStyle = (field) => (
+    parseInfo?.needsReview?.includes(field)
+      ? { background: 'rgba(255, 193, 7, 0.2)', borderLeft: '3px solid #ffc107', paddingLeft: '8px' }
+      : {}
+  )
+
   const handleFieldChange = (field, value) => {
     setEditedJob(prev => ({
       ...prev,
@@ -102,15 +178,17 @@ function TrackJob() {
               minWidth: '120px'
             }}
           >
-            <option value="linkedin" style={{ background: '#333', color: 'white' }}>LinkedIn</option>
-            <option value="greenhouse" style={{ background: '#333', color: 'white' }}>Greenhouse</option>
+            <option value="auto" style={{ background: '#333', color: 'white' }}>Auto-detect</option>
+            {Object.entries(platformLabels).map(([value, label]) => (
+              <option key={value} value={value} style={{ background: '#333', color: 'white' }}>{label}</option>
+            ))}
           </select>
         </div>
         
         <textarea
           value={jobText}
           onChange={(e) => setJobText(e.target.value)}
-          placeholder={`Enter a job application from ${platform === 'linkedin' ? 'LinkedIn' : 'Greenhouse'}...`}
+          placeholder={platform === 'auto' ? 'Paste a job application copied with the Chrome extension...' : `Enter a job application from ${platformLabels[platform]}...`}
           disabled={isLoading}
         />
         <button
@@ -125,7 +203,10 @@ function TrackJob() {
         <>
           <div style={{ marginTop: '20px', textAlign: 'left', background: 'rgba(255,255,255,0.1)', padding: '15px', borderRadius: '10px' }}>
             <div style={{ display: 'flex', justifyContent: 'space-between', alignItems: 'center', marginBottom: '15px' }}>
-              <h3 style={{ margin: 0 }}>Job Application Details:</h3>
+              <h3 style={{ margin: 0 }}>
+                Job Application Details:
+                {parseInfo && <span style={{ fontSize: '14px', fontWeight: 'normal', marginLeft: '10px', opacity: 0.8 }}>parsed with {parseInfo.parser}</span>}
+              </h3>
               {!isEditing ? (
                 <button 
                   onClick={handleEditClick}
@@ -161,6 +242,14 @@ function TrackJob() {
               )}
             </div>
 
+            {parseInfo?.warnings?.length > 0 && (
+              <ul style={{ margin: '0 0 15px 0', padding: '10px 10px 10px 30px', background: 'rgba(255, 193, 7, 0.15)', borderRadius: '5px' }}>
+                {parseInfo.warnings.map((warning) => (
+                  <li key={warning}>⚠️ {warning}</li>
+                ))}
+              </ul>
+            )}
+
             {isEditing ? (
               <div style={{ display: 'grid', gap: '15px' }}>
                 <div>
@@ -263,22 +352,33 @@ function TrackJob() {
                       fontSize: '14px'
                     }}
                   >
-                    <option value="SUBMITTED">SUBMITTED</option>
-                    <option value="REJECTED">REJECTED</option>
-                    <option value="PHONE_SCREEN">PHONE_SCREEN</option>
-                    <option value="REMOTE_INTERVIEW">REMOTE_INTERVIEW</option>
-                    <option value="ON_SITE_INTERVIEW">ON_SITE_INTERVIEW</option>
+                    {stages.filter((stage) => !stage.terminal).map((stage) => (
+                      <option key={stage.name} value={stage.name}>{stage.label}</option>
+                    ))}
                   </select>
                 </div>
               </div>
             ) : (
               <div>
-                <p><strong>Company:</strong> {(editedJob || parsedJob).company || 'Not found'}</p>
-                <p><strong>Position:</strong> {(editedJob || parsedJob).position || 'Not found'}</p>
-                <p><strong>Location:</strong> {(editedJob || parsedJob).location || 'Not found'}</p>
-                <p><strong>Salary Range:</strong> {(editedJob || parsedJob).salaryRange || 'Not found'}</p>
-                <p><strong>Workplace Type:</strong> {(editedJob || parsedJob).workplaceType || 'Not found'}</p>
+                <p style={reviewStyle('company')}><strong>Company:</strong> {(editedJob || parsedJob).company || 'Not found'}</p>
+                <p style={reviewStyle('position')}><strong>Position:</strong> {(editedJob || parsedJob).position || 'Not found'}</p>
+                <p style={reviewStyle('location')}><strong>Location:</strong> {(editedJob || parsedJob).location || 'Not found'}</p>
+                <p style={reviewStyle('salaryRange')}><strong>Salary Range:</strong> {(editedJob || parsedJob).salaryRange || 'Not found'}</p>
+                {formatSalary(editedJob || parsedJob) && <p><strong>Parsed Salary:</strong> {formatSalary(editedJob || parsedJob)}</p>}
+                <p style={reviewStyle('workplaceType')}><strong>Workplace Type:</strong> {(editedJob || parsedJob).workplaceType || 'Not found'}</p>
+                <p><strong>Employment Type:</strong> {(editedJob || parsedJob).employmentType || 'Not found'}</p>
+                <p><strong>Department:</strong> {(editedJob || parsedJob).department || 'Not found'}</p>
+                {(editedJob || parsedJob).datePosted && <p><strong>Date Posted:</strong> {(editedJob || parsedJob).datePosted}</p>}
+                {(editedJob || parsedJob).requisitionId && <p><strong>Requisition ID:</strong> {(editedJob || parsedJob).requisitionId}</p>}
+                {(editedJob || parsedJob).canonicalUrl && <p><strong>Source:</strong> {(editedJob || parsedJob).canonicalUrl}</p>}
+                {(editedJob || parsedJob).boardJobId && <p><strong>Board Job ID:</strong> {(editedJob || parsedJob).boardJobId}</p>}
                 <p><strong>Status:</strong> {(editedJob || parsedJob).status}</p>
+                {(editedJob || parsedJob).description && (
+                  <details>
+                    <summary><strong>Description</strong></summary>
+                    <pre style={{ whiteSpace: 'pre-wrap', fontFamily: 'inherit' }}>{(editedJob || parsedJob).description}</pre>
+                  </details>
+                )}
               </div>
             )}
           </div>
@@ -316,6 +416,30 @@ function TrackJob() {
             >
               {isSaving ? 'Saving...' : 'Save Job Application'}
             </button>
+
+            {duplicates.length > 0 && (
+              <div style={{ marginTop: '20px', padding: '15px', border: '1px solid #f0ad4e', borderRadius: '5px', backgroundColor: '#fff8e1' }}>
+                <h4>This looks like an application you already saved</h4>
+                {duplicates.map((candidate) => (
+                  <div key={candidate.app.appId} style={{ marginBottom: '10px' }}>
+                    <p>
+                      <strong>{candidate.app.position}</strong> at {candidate.app.company}, applied {candidate.app.dateApplied} ({candidate.app.status})
+                      <br />
+                      <em>{candidate.reasons.join(', ')}</em>
+                    </p>
+                    <button onClick={() => handleMerge(candidate.app.appId)} disabled={isSaving}>
+                      Merge into this one
+                    </button>
+                  </div>
+                ))}
+                <button onClick={resetForm} disabled={isSaving} style={{ marginRight: '10px' }}>
+                  Skip
+                </button>
+                <button onClick={handleSaveAnyway} disabled={isSaving}>
+                  Save anyway
+                </button>
+              </div>
+            )}
           </div>
         </>
       )}
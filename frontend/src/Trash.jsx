@@ -0,0 +1,98 @@
+import { useState, useEffect } from 'react'
+import './search.css'
+
+function Trash() {
+    const [apps, setApps] = useState([])
+    const [isLoading, setIsLoading] = useState(true)
+
+    const loadTrash = async () => {
+        try {
+            const apps = await window.go.main.App.GetTrash()
+            setApps(apps || [])
+        } catch (error) {
+            console.error("Error loading trash:", error)
+        } finally {
+            setIsLoading(false)
+        }
+    }
+
+    useEffect(() => {
+        loadTrash()
+        window.addEventListener('jobapps-changed', loadTrash)
+        return () => window.removeEventListener('jobapps-changed', loadTrash)
+    }, [])
+
+    const handleRestore = async (appId) => {
+        try {
+            await window.go.main.App.RestoreJobApp(appId)
+            setApps((current) => current.filter((app) => app.appId !== appId))
+        } catch (error) {
+            console.error("Error restoring job:", error)
+            alert("Error restoring job: " + error)
+        }
+    }
+
+    const handlePurge = async (app) => {
+        if (!window.confirm(`Permanently delete ${app.position} at ${app.company}?`)) {
+            return
+        }
+        try {
+            await window.go.main.App.PurgeJobApp(app.appId)
+            setApps((current) => current.filter((candidate) => candidate.appId !== app.appId))
+        } catch (error) {
+            console.error("Error purging job:", error)
+            alert("Error purging job: " + error)
+        }
+    }
+
+    const handleEmptyTrash = async () => {
+        if (!window.confirm(`Permanently delete all ${apps.length} applications in the trash?`)) {
+            return
+        }
+        try {
+            await window.go.main.App.EmptyTrash()
+            setApps([])
+        } catch (error) {
+            console.error("Error emptying trash:", error)
+            alert("Error emptying trash: " + error)
+        }
+    }
+
+    if (isLoading) {
+        return <div>Loading...</div>
+    }
+
+    return (
+        <div className="search-container">
+            <div className="search-bar">
+                <p>{apps.length === 0 ? 'The trash is empty.' : `${apps.length} deleted applications`}</p>
+                {apps.length > 0 && (
+                    <button onClick={handleEmptyTrash} className="search-button job-detail-delete">
+                        Empty trash
+                    </button>
+                )}
+            </div>
+
+            <div className="search-results">
+                {apps.map((app) => (
+                    <div className="result-item" key={app.appId}>
+                        <h3>{app.company}</h3>
+                        <p>{app.position}</p>
+                        <p>{app.dateApplied}</p>
+                        <p>Deleted {new Date(app.deletedAt).toLocaleString()}</p>
+                        <p>
+                            <button onClick={() => handleRestore(app.appId)} className="search-button">
+                                Restore
+                            </button>
+                            <button onClick={() => handlePurge(app)} className="search-button job-detail-delete">
+                                Delete forever
+                            </button>
+                        </p>
+                    </div>
+                ))}
+            </div>
+        </div>
+    )
+}
+
+export default Trash
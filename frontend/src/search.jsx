@@ -1,5 +1,6 @@
 import { useState, useEffect } from 'react'
 import './search.css'
+import JobDetail from './JobDetail'
 
 const SearchType = Object.freeze({
     COMPANY: 'company',
@@ -7,34 +8,267 @@ const SearchType = Object.freeze({
     FULL_TEXT: 'full text',
 })
 
+// SortOptions are the sort orders offered for QueryJobApps
+const SortOptions = [
+    { label: 'Newest applied', sort: [{ field: 'dateApplied', desc: true }] },
+    { label: 'Oldest applied', sort: [{ field: 'dateApplied', desc: false }] },
+    { label: 'Highest pay', sort: [{ field: 'salary', desc: true }] },
+    { label: 'Company A–Z', sort: [{ field: 'company', desc: false }, { field: 'dateApplied', desc: true }] },
+    { label: 'Status', sort: [{ field: 'status', desc: false }, { field: 'dateApplied', desc: true }] },
+]
+const RankByPaySort = 2
+
+const WorkplaceTypes = ['Remote', 'Hybrid', 'On-site']
+
+const PageSize = 20
+
+const emptyFilters = {
+    status: '',
+    workplaceType: '',
+    location: '',
+    appliedFrom: '',
+    appliedTo: '',
+    salaryMin: '',
+    salaryMax: '',
+    tags: '',
+    sort: 0,
+}
+
+// highlightSnippet turns the \x02 and \x03 markers around full text matches into <mark>
+function highlightSnippet(snippet) {
+    return snippet.split('\x02').map((part, i) => {
+        const [match, rest] = part.split('\x03')
+        if (i === 0 || rest === undefined) {
+            return <span key={i}>{part}</span>
+        }
+        return <span key={i}><mark>{match}</mark>{rest}</span>
+    })
+}
+
 function Search() {
     const [searchTerm, setSearchTerm] = useState('')
     const [searchType, setSearchType] = useState(SearchType.COMPANY)
     const [results, setResults] = useState([])
     const [isLoading, setIsLoading] = useState(false)
+    const [openDescription, setOpenDescription] = useState(null)
+    const [timelines, setTimelines] = useState({})
+    const [stages, setStages] = useState([])
+    const [editing, setEditing] = useState(null)
+    const [filters, setFilters] = useState(emptyFilters)
+    const [page, setPage] = useState({ total: 0, offset: 0 })
+    const [reloadKey, setReloadKey] = useState(0)
+    const [views, setViews] = useState([])
+    const [activeView, setActiveView] = useState(null)
 
     useEffect(() => {
-        const fetchResults = async () => {
-            const results = await window.go.main.App.GetAllJobApps()
-            console.log(results)
-            // get last 20 results
-            setResults(results)
+        window.go.main.App.GetStatusPipeline().then(setStages)
+
+        // Reload after an undo or redo, starting over after a profile switch
+        const handleChanged = (e) => {
+            setTimelines({})
+            if (e.detail?.profile) {
+                setActiveView(null)
+                setEditing(null)
+                setPage({ total: 0, offset: 0 })
+            }
+            setReloadKey((key) => key + 1)
         }
-        fetchResults()
+        window.addEventListener('jobapps-changed', handleChanged)
+        return () => window.removeEventListener('jobapps-changed', handleChanged)
     }, [])
 
+    useEffect(() => {
+        runQuery(page.offset)
+    }, [reloadKey])
+
+    // loadViews refreshes the saved searches and their counts
+    const loadViews = async () => {
+        try {
+            setViews(await window.go.main.App.GetSavedSearches() || [])
+        } catch (error) {
+            console.error("Error loading saved searches:", error)
+        }
+    }
+
+    // buildQuery turns the search term and filters into a QueryJobApps query
+    const buildQuery = (current) => {
+        const term = searchTerm.trim()
+        return {
+            company: searchType === SearchType.COMPANY ? term : '',
+            position: searchType === SearchType.POSITION ? term : '',
+            statuses: current.status ? [current.status] : [],
+            workplaceTypes: current.workplaceType ? [current.workplaceType] : [],
+            location: current.location,
+            appliedFrom: current.appliedFrom || null,
+            appliedTo: current.appliedTo || null,
+            salaryMin: Number(current.salaryMin) || 0,
+            salaryMax: Number(current.salaryMax) || 0,
+            tags: current.tags.split(',').map((tag) => tag.trim()).filter(Boolean),
+            sort: SortOptions[current.sort].sort,
+        }
+    }
+
+    // runQuery loads one page of applications matching the active view, or
+    // the search term and filters when no view is active
+    const runQuery = async (offset = 0, current = filters, view = activeView) => {
+        setIsLoading(true)
+        try {
+            const query = view ? view.query : buildQuery(current)
+            const result = await window.go.main.App.QueryJobApps({ ...query, limit: PageSize, offset })
+            setResults(result.apps)
+            setPage({ total: result.total, offset: result.offset })
+            loadViews()
+        } catch (error) {
+            console.error("Error querying:", error)
+            alert("Error querying: " + error)
+        } finally {
+            setIsLoading(false)
+        }
+    }
+
+    const setFilter = (field, value) => {
+        setFilters((current) => ({ ...current, [field]: value }))
+    }
+
+    const handleApplyFilters = () => {
+        setActiveView(null)
+        runQuery(0, filters, null)
+    }
+
+    const handleClearFilters = () => {
+        setFilters(emptyFilters)
+        setActiveView(null)
+        runQuery(0, emptyFilters, null)
+    }
+
+    const handleSelectView = (view) => {
+        setActiveView(view)
+        runQuery(0, filters, view)
+    }
+
+    const handleSaveView = async () => {
+        const name = window.prompt('Name for this view:')
+        if (!name?.trim()) {
+            return
+        }
+        try {
+            const view = await window.go.main.App.SaveSearch({ name, query: buildQuery(filters), pinned: true, position: views.length })
+            handleSelectView(view)
+        } catch (error) {
+            console.error("Error saving view:", error)
+            alert("Error saving view: " + error)
+        }
+    }
+
+    // updateView saves changes to the active view
+    const updateView = async (changes) => {
+        try {
+            const view = await window.go.main.App.SaveSearch({ ...activeView, ...changes })
+            setActiveView(view)
+            loadViews()
+        } catch (error) {
+            console.error("Error saving view:", error)
+            alert("Error saving view: " + error)
+        }
+    }
+
+    const handleRenameView = () => {
+        const name = window.prompt('New name for this view:', activeView.name)
+        if (name?.trim()) {
+            updateView({ name })
+        }
+    }
+
+    const handleDeleteView = async () => {
+        if (!window.confirm(`Delete the view "${activeView.name}"?`)) {
+            return
+        }
+        try {
+            await window.go.main.App.DeleteSavedSearch(activeView.id)
+            handleSelectView(null)
+        } catch (error) {
+            console.error("Error deleting view:", error)
+            alert("Error deleting view: " + error)
+        }
+    }
+
+    const stageLabel = (name) => stages.find((stage) => stage.name === name)?.label || name
+
+    // The current status plus the statuses the pipeline allows moving to
+    const statusOptions = (current) => {
+        const stage = stages.find((candidate) => candidate.name === current)
+        return [current, ...(stage?.next || [])]
+    }
+
     const handleSearch = async () => {
+        if (searchType !== SearchType.FULL_TEXT) {
+            handleApplyFilters()
+            return
+        }
         setIsLoading(true)
         try {
-            const results = await window.go.main.App.SearchByCompany(searchTerm)
+            const results = await window.go.main.App.SearchJobApps(searchTerm)
             setResults(results)
+            setPage({ total: results.length, offset: 0 })
         } catch (error) {
             console.error("Error searching:", error)
+            alert("Error searching: " + error)
         } finally {
             setIsLoading(false)
         }
     }
 
+    const handleRankByPay = () => {
+        const next = { ...filters, sort: RankByPaySort }
+        setFilters(next)
+        setActiveView(null)
+        runQuery(0, next, null)
+    }
+
+    const loadTimeline = async (appId) => {
+        const events = await window.go.main.App.GetStatusTimeline(appId)
+        setTimelines((current) => ({ ...current, [appId]: events || [] }))
+    }
+
+    const toggleTimeline = async (appId) => {
+        if (timelines[appId]) {
+            setTimelines(({ [appId]: _, ...rest }) => rest)
+            return
+        }
+        try {
+            await loadTimeline(appId)
+        } catch (error) {
+            console.error("Error loading timeline:", error)
+        }
+    }
+
+    const handleStatusChange = async (appId, status) => {
+        const note = window.prompt(`Note for the move to ${status} (optional):`) ?? ''
+        try {
+            await window.go.main.App.UpdateJobAppStatus(appId, status, note.trim())
+            setResults((current) => current.map((result) => result.appId === appId ? { ...result, status } : result))
+            if (timelines[appId]) {
+                await loadTimeline(appId)
+            }
+        } catch (error) {
+            console.error("Error updating status:", error)
+            alert("Error updating status: " + error.message)
+        }
+    }
+
+    const handleSaved = (updated) => {
+        setResults((current) => current.map((result) => result.appId === updated.appId ? updated : result))
+        if (timelines[updated.appId]) {
+            loadTimeline(updated.appId)
+        }
+    }
+
+    const handleDeleted = (appId) => {
+        setResults((current) => current.filter((result) => result.appId !== appId))
+        setPage((current) => ({ ...current, total: current.total - 1 }))
+        setEditing(null)
+    }
+
     const handleKeyPress = (e) => {
         if (e.key === 'Enter') {
             handleSearch()
@@ -54,13 +288,13 @@ function Search() {
                                 value={searchTerm}
                                 onChange={(e) => setSearchTerm(e.target.value)}
                                 onKeyPress={handleKeyPress}
-                                placeholder="Enter company name..."
+                                placeholder={searchType === SearchType.FULL_TEXT ? 'e.g. golang OR rust, platform*, "staff engineer"' : `Enter ${searchType} name...`}
                                 className="search-input"
                                 disabled={isLoading}
                             />
                             <button
                                 onClick={handleSearch}
-                                disabled={isLoading || !searchTerm.trim()}
+                                disabled={isLoading || (searchType === SearchType.FULL_TEXT && !searchTerm.trim())}
                                 className="search-button"
                             >
                                 {isLoading ? '⏳' : '🔍'}
@@ -78,6 +312,111 @@ function Search() {
                                 <option value={SearchType.POSITION}>Position</option>
                                 <option value={SearchType.FULL_TEXT}>Full Text</option>
                             </select>
+                            <button onClick={handleRankByPay} className="search-button">
+                                Rank by pay
+                            </button>
+                        </div>
+
+                        <div className="search-filters">
+                            <select value={filters.status} onChange={(e) => setFilter('status', e.target.value)} className="search-select">
+                                <option value="">Any status</option>
+                                {stages.map((stage) => (
+                                    <option key={stage.name} value={stage.name}>{stage.label}</option>
+                                ))}
+                            </select>
+                            <select value={filters.workplaceType} onChange={(e) => setFilter('workplaceType', e.target.value)} className="search-select">
+                                <option value="">Any workplace</option>
+                                {WorkplaceTypes.map((type) => (
+                                    <option key={type} value={type}>{type}</option>
+                                ))}
+                            </select>
+                            <input
+                                type="text"
+                                value={filters.location}
+                                onChange={(e) => setFilter('location', e.target.value)}
+                                onKeyPress={handleKeyPress}
+                                placeholder="Location"
+                                className="search-input"
+                            />
+                            <label>
+                                Applied from <input type="date" value={filters.appliedFrom} onChange={(e) => setFilter('appliedFrom', e.target.value)} className="search-input" />
+                            </label>
+                            <label>
+                                to <input type="date" value={filters.appliedTo} onChange={(e) => setFilter('appliedTo', e.target.value)} className="search-input" />
+                            </label>
+                            <input
+                                type="number"
+                                value={filters.salaryMin}
+                                onChange={(e) => setFilter('salaryMin', e.target.value)}
+                                onKeyPress={handleKeyPress}
+                                placeholder="Min pay / year"
+                                className="search-input"
+                            />
+                            <input
+                                type="number"
+                                value={filters.salaryMax}
+                                onChange={(e) => setFilter('salaryMax', e.target.value)}
+                                onKeyPress={handleKeyPress}
+                                placeholder="Max pay / year"
+                                className="search-input"
+                            />
+                            <input
+                                type="text"
+                                value={filters.tags}
+                                onChange={(e) => setFilter('tags', e.target.value)}
+                                onKeyPress={handleKeyPress}
+                                placeholder="Tags, comma separated"
+                                className="search-input"
+                            />
+                            <select value={filters.sort} onChange={(e) => setFilter('sort', Number(e.target.value))} className="search-select">
+                                {SortOptions.map((option, i) => (
+                                    <option key={option.label} value={i}>{option.label}</option>
+                                ))}
+                            </select>
+                            <button onClick={handleApplyFilters} className="search-button">
+                                Apply filters
+                            </button>
+                            <button onClick={handleClearFilters} className="search-button">
+                                Clear
+                            </button>
+                            <button onClick={handleSaveView} className="search-button">
+                                Save as view
+                            </button>
+                        </div>
+
+                        <div className="search-views">
+                            {views.filter((view) => view.pinned).map((view) => (
+                                <button
+                                    key={view.id}
+                                    onClick={() => handleSelectView(activeView?.id === view.id ? null : view)}
+                                    className={`search-view${activeView?.id === view.id ? ' active' : ''}`}
+                                >
+                                    {view.name} <span className="search-view-count">{view.count}</span>
+                                </button>
+                            ))}
+                            <select
+                                value={activeView?.id ?? ''}
+                                onChange={(e) => handleSelectView(views.find((view) => view.id === Number(e.target.value)) || null)}
+                                className="search-select"
+                            >
+                                <option value="">All views</option>
+                                {views.map((view) => (
+                                    <option key={view.id} value={view.id}>{view.name} ({view.count})</option>
+                                ))}
+                            </select>
+                            {activeView && (
+                                <>
+                                    <button onClick={() => updateView({ pinned: !activeView.pinned })} className="search-button">
+                                        {activeView.pinned ? 'Unpin' : 'Pin'}
+                                    </button>
+                                    <button onClick={handleRenameView} className="search-button">
+                                        Rename
+                                    </button>
+                                    <button onClick={handleDeleteView} className="search-button job-detail-delete">
+                                        Delete view
+                                    </button>
+                                </>
+                            )}
                         </div>
                     </div>
 
@@ -86,16 +425,101 @@ function Search() {
                         <div className="result-item" key={result.appId}>
                             <h3>{result.company}</h3>
                             <p>{result.position}</p>
+                            {result.snippet && (
+                                <p className="result-snippet">{highlightSnippet(result.snippet)}</p>
+                            )}
                             <p>{result.location}</p>
                             <p>{result.dateApplied}</p>
-                            <p>{result.status}</p>
+                            <p>
+                                <select
+                                    value={result.status}
+                                    onChange={(e) => handleStatusChange(result.appId, e.target.value)}
+                                    className="search-select"
+                                >
+                                    {statusOptions(result.status).map((status) => (
+                                        <option key={status} value={status}>{stageLabel(status)}</option>
+                                    ))}
+                                </select>
+                                <button onClick={() => toggleTimeline(result.appId)} className="search-button">
+                                    {timelines[result.appId] ? 'Hide timeline' : 'Timeline'}
+                                </button>
+                                <button onClick={() => setEditing(editing === result.appId ? null : result.appId)} className="search-button">
+                                    {editing === result.appId ? 'Close' : 'Edit'}
+                                </button>
+                            </p>
+                            {editing === result.appId && (
+                                <JobDetail
+                                    appId={result.appId}
+                                    stages={stages}
+                                    onSaved={handleSaved}
+                                    onDeleted={handleDeleted}
+                                    onClose={() => setEditing(null)}
+                                />
+                            )}
+                            {timelines[result.appId] && (
+                                <ul className="result-timeline">
+                                    {timelines[result.appId].map((event) => (
+                                        <li key={event.id}>
+                                            {new Date(event.changedAt).toLocaleString()}: {event.fromStatus ? `${stageLabel(event.fromStatus)} → ` : ''}{stageLabel(event.toStatus)}
+                                            {event.note && <em> ({event.note})</em>}
+                                        </li>
+                                    ))}
+                                </ul>
+                            )}
                             <p>{result.notes}</p>
                             <p>{result.website}</p>
+                            {result.sourceUrl && (
+                                <p>
+                                    <a href={result.sourceUrl} onClick={(e) => { e.preventDefault(); window.runtime.BrowserOpenURL(result.canonicalUrl || result.sourceUrl) }}>
+                                        View posting
+                                    </a>
+                                    {result.boardJobId && <span> (job ID {result.boardJobId})</span>}
+                                </p>
+                            )}
                             <p>{result.salaryRange}</p>
+                            {result.annualizedSalary > 0 && (
+                                <p>≈ {Math.round(result.annualizedSalary).toLocaleString()} {result.annualizedCurrency} / year</p>
+                            )}
                             <p>{result.workplaceType}</p>
+                            {result.tags?.length > 0 && (
+                                <p className="result-tags">
+                                    {result.tags.map((tag) => <span key={tag} className="result-tag">{tag}</span>)}
+                                </p>
+                            )}
+                            {result.description && (
+                                <button
+                                    onClick={() => setOpenDescription(openDescription === result.appId ? null : result.appId)}
+                                    className="search-button"
+                                >
+                                    {openDescription === result.appId ? 'Hide description' : 'View description'}
+                                </button>
+                            )}
+                            {openDescription === result.appId && (
+                                <pre className="result-description">{result.description}</pre>
+                            )}
                         </div>
                     ))}
                 </div>
+
+                    <div className="search-pagination">
+                        <button
+                            onClick={() => runQuery(Math.max(page.offset - PageSize, 0))}
+                            disabled={page.offset === 0}
+                            className="search-button"
+                        >
+                            Previous
+                        </button>
+                        <span>
+                            {page.total === 0 ? 'No applications' : `${page.offset + 1}–${page.offset + results.length} of ${page.total}`}
+                        </span>
+                        <button
+                            onClick={() => runQuery(page.offset + PageSize)}
+                            disabled={page.offset + results.length >= page.total}
+                            className="search-button"
+                        >
+                            Next
+                        </button>
+                    </div>
                 </div>
             </>
         ))
@@ -4,6 +4,7 @@ go 1.25.1
 
 require (
 	github.com/wailsapp/wails/v2 v2.10.2
+	golang.org/x/net v0.43.0
 	golang.org/x/oauth2 v0.31.0
 	google.golang.org/api v0.249.0
 	gorm.io/driver/sqlite v1.5.4
@@ -57,7 +58,6 @@ require (
 	go.opentelemetry.io/otel/trace v1.37.0 // indirect
 	golang.org/x/crypto v0.41.0 // indirect
 	golang.org/x/exp v0.0.0-20250620022241-b7579e27df2b // indirect
-	golang.org/x/net v0.43.0 // indirect
 	golang.org/x/sys v0.35.0 // indirect
 	golang.org/x/text v0.28.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250818200422-3122310a409c // indirect
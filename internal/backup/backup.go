@@ -21,12 +21,13 @@ type BackupService struct {
 	ctx     context.Context
 }
 
-// NewBackupService creates a new backup service
-func NewBackupService() (*BackupService, error) {
+// NewBackupService creates a new backup service from the OAuth client in
+// credentialsPath, caching the user's token in tokenPath
+func NewBackupService(credentialsPath string, tokenPath string) (*BackupService, error) {
 	ctx := context.Background()
 
 	// Load credentials from file (you'll need to set this up)
-	credentials, err := os.ReadFile("credentials.json")
+	credentials, err := os.ReadFile(credentialsPath)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read credentials file: %v", err)
 	}
@@ -36,7 +37,7 @@ func NewBackupService() (*BackupService, error) {
 		return nil, fmt.Errorf("unable to parse client secret file to config: %v", err)
 	}
 
-	client := getClient(config)
+	client := getClient(config, tokenPath)
 
 	srv, err := drive.NewService(ctx, option.WithHTTPClient(client))
 	if err != nil {
@@ -46,8 +47,8 @@ func NewBackupService() (*BackupService, error) {
 	return &BackupService{service: srv, ctx: ctx}, nil
 }
 
-// BackupDatabase uploads the SQLite database to Google Drive
-func (bs *BackupService) BackupDatabase(dbPath string) error {
+// BackupDatabase uploads the SQLite database to Google Drive as backupName
+func (bs *BackupService) BackupDatabase(dbPath string, backupName string) error {
 	file, err := os.Open(dbPath)
 	if err != nil {
 		return fmt.Errorf("unable to open database file: %v", err)
@@ -55,14 +56,14 @@ func (bs *BackupService) BackupDatabase(dbPath string) error {
 	defer file.Close()
 
 	// Check if backup already exists
-	existingFileID, err := bs.findExistingBackup()
+	existingFileID, err := bs.findExistingBackup(backupName)
 	if err != nil {
 		log.Printf("Warning: Could not check for existing backup: %v", err)
 	}
 
 	// Create file metadata - remove appDataFolder
 	driveFile := &drive.File{
-		Name: "job_apps_backup.db",
+		Name: backupName,
 		// Remove Parents line - will go to root Drive folder
 	}
 
@@ -87,9 +88,9 @@ func (bs *BackupService) BackupDatabase(dbPath string) error {
 }
 
 // findExistingBackup finds existing backup file ID
-func (bs *BackupService) findExistingBackup() (string, error) {
+func (bs *BackupService) findExistingBackup(backupName string) (string, error) {
 	files, err := bs.service.Files.List().
-		Q("name='job_apps_backup.db'").
+		Q(fmt.Sprintf("name='%s' and trashed=false", backupName)).
 		Do()
 	if err != nil {
 		return "", err
@@ -102,8 +103,7 @@ func (bs *BackupService) findExistingBackup() (string, error) {
 }
 
 // getClient retrieves a token, saves the token, then returns the generated client.
-func getClient(config *oauth2.Config) *http.Client {
-	tokFile := "token.json"
+func getClient(config *oauth2.Config, tokFile string) *http.Client {
 	tok, err := tokenFromFile(tokFile)
 	if err != nil {
 		tok = getTokenFromWeb(config)
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Config holds the user's local settings
+type Config struct {
+	// HomeCurrency is the ISO code every salary is normalized into
+	HomeCurrency string `json:"homeCurrency"`
+	// HoursPerYear converts hourly pay into an annual base
+	HoursPerYear float64 `json:"hoursPerYear"`
+	// WorkDaysPerYear converts daily pay into an annual base
+	WorkDaysPerYear float64 `json:"workDaysPerYear"`
+	// ExchangeRates maps a currency code to its value in the home currency
+	ExchangeRates map[string]float64 `json:"exchangeRates"`
+	// Statuses adds custom stages to the application status pipeline
+	Statuses []Stage `json:"statuses"`
+	// TrashRetentionDays is how long deleted applications stay in the trash;
+	// a negative value keeps them until the trash is emptied
+	TrashRetentionDays int `json:"trashRetentionDays"`
+	// UndoDepth is how many changes can be undone
+	UndoDepth int `json:"undoDepth"`
+}
+
+// Stage is a custom status pipeline stage
+type Stage struct {
+	// Name is the status stored on applications, e.g. "RECRUITER_CHAT"
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	// After is the stage this one is listed after; it goes last when empty
+	After string `json:"after"`
+	// Terminal stages end an application
+	Terminal bool `json:"terminal"`
+	// Next limits the stages that can follow this one
+	Next []string `json:"next"`
+}
+
+// Default returns the settings used when no config file exists
+func Default() *Config {
+	return &Config{
+		HomeCurrency:       "USD",
+		HoursPerYear:       2080,
+		WorkDaysPerYear:    260,
+		ExchangeRates:      map[string]float64{"USD": 1},
+		TrashRetentionDays: 30,
+		UndoDepth:          50,
+	}
+}
+
+// Load reads the config file at path, filling unset values with defaults.
+// A missing file is not an error.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file: %v", err)
+	}
+
+	loaded := &Config{}
+	if err := json.Unmarshal(data, loaded); err != nil {
+		return nil, fmt.Errorf("unable to parse config file: %v", err)
+	}
+
+	if loaded.HomeCurrency != "" {
+		cfg.HomeCurrency = strings.ToUpper(loaded.HomeCurrency)
+	}
+	if loaded.HoursPerYear > 0 {
+		cfg.HoursPerYear = loaded.HoursPerYear
+	}
+	if loaded.WorkDaysPerYear > 0 {
+		cfg.WorkDaysPerYear = loaded.WorkDaysPerYear
+	}
+	// Loaded rates replace the default USD one, even when there are none, so
+	// another home currency does not count USD as 1:1
+	cfg.ExchangeRates = map[string]float64{}
+	for currency, rate := range loaded.ExchangeRates {
+		cfg.ExchangeRates[strings.ToUpper(currency)] = rate
+	}
+	if loaded.TrashRetentionDays != 0 {
+		cfg.TrashRetentionDays = loaded.TrashRetentionDays
+	}
+	if loaded.UndoDepth > 0 {
+		cfg.UndoDepth = loaded.UndoDepth
+	}
+	for _, stage := range loaded.Statuses {
+		if strings.TrimSpace(stage.Name) == "" {
+			return nil, fmt.Errorf("unable to parse config file: status stage without a name")
+		}
+		cfg.Statuses = append(cfg.Statuses, stage)
+	}
+	// The home currency always converts to itself
+	cfg.ExchangeRates[cfg.HomeCurrency] = 1
+
+	return cfg, nil
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadHomeCurrencyWithoutRates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"homeCurrency": "eur"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.HomeCurrency != "EUR" {
+		t.Errorf("Expected home currency EUR, got %s", cfg.HomeCurrency)
+	}
+	if len(cfg.ExchangeRates) != 1 || cfg.ExchangeRates["EUR"] != 1 {
+		t.Errorf("Expected only EUR to convert, got %v", cfg.ExchangeRates)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.HomeCurrency != "USD" || cfg.ExchangeRates["USD"] != 1 {
+		t.Errorf("Expected the defaults, got %+v", cfg)
+	}
+}
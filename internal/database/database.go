@@ -2,8 +2,11 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -12,57 +15,124 @@ import (
 	"track-my-job-apps/internal/models"
 )
 
-var db *gorm.DB
+// MemoryDSN opens a private in-memory database, for tests and previews
+const MemoryDSN = ":memory:"
 
-// InitDatabase initializes the SQLite database connection and creates tables
-func InitDatabase() error {
-	var err error
+// Store is a SQLite database of job applications. Each Store owns its
+// connection, so several databases can be open at once.
+type Store struct {
+	db *gorm.DB
+	// dsn is the file or DSN the store was opened from
+	dsn string
+}
 
-	// Open SQLite database with pure Go driver
-	sqlDB, err := sql.Open("sqlite", "job_apps.db?_pragma=foreign_keys(1)")
+// ErrDuplicateApp is returned when an application with the same company,
+// position and date applied is already saved
+var ErrDuplicateApp = errors.New("application already saved")
+
+// NewStore opens the database at dsn and applies the pending schema
+// migrations
+func NewStore(dsn string) (*Store, error) {
+	s, err := Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := s.Migrate(false)
 	if err != nil {
-		return fmt.Errorf("failed to open database: %v", err)
+		s.Close()
+		return nil, fmt.Errorf("failed to migrate database: %v", err)
+	}
+	if len(applied) > 0 {
+		log.Printf("Applied %d database migrations to %s", len(applied), dsn)
+	}
+
+	log.Printf("Database %s initialized successfully", dsn)
+	return s, nil
+}
+
+// Open connects to the SQLite database at dsn without migrating it. dsn is
+// a file path, optionally with query parameters, or MemoryDSN.
+func Open(dsn string) (*Store, error) {
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
 	}
 
-	db, err = gorm.Open(sqlite.Dialector{Conn: sqlDB}, &gorm.Config{})
+	// Open SQLite database with pure Go driver
+	sqlDB, err := sql.Open("sqlite", dsn+separator+"_pragma=foreign_keys(1)")
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %v", err)
+		return nil, fmt.Errorf("failed to open database: %v", err)
+	}
+	if isMemory(dsn) {
+		// Every connection to :memory: gets its own empty database
+		sqlDB.SetMaxOpenConns(1)
 	}
 
-	// Auto-migrate the schema
-	err = db.AutoMigrate(&models.JobApplication{})
+	db, err := gorm.Open(sqlite.Dialector{Conn: sqlDB}, &gorm.Config{})
 	if err != nil {
-		return fmt.Errorf("failed to migrate database: %v", err)
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to connect to database: %v", err)
 	}
+	return &Store{db: db, dsn: dsn}, nil
+}
+
+// isMemory reports whether dsn opens an in-memory database
+func isMemory(dsn string) bool {
+	return strings.HasPrefix(dsn, MemoryDSN) || strings.HasPrefix(dsn, "file::memory:") || strings.Contains(dsn, "mode=memory")
+}
 
-	// Create FTS5 virtual table for search
-	err = createFTSTable()
+// Close closes the database connection
+func (s *Store) Close() error {
+	sqlDB, err := s.db.DB()
 	if err != nil {
-		return fmt.Errorf("failed to create FTS table: %v", err)
+		return fmt.Errorf("failed to close database: %v", err)
 	}
+	return sqlDB.Close()
+}
 
-	log.Println("Database initialized successfully")
-	return nil
+// DB returns the underlying connection
+func (s *Store) DB() *gorm.DB {
+	return s.db
 }
 
-// GetDB returns the database instance
-func GetDB() *gorm.DB {
-	return db
+// Path returns the file the database was opened from, without query
+// parameters, or "" for an in-memory database
+func (s *Store) Path() string {
+	if isMemory(s.dsn) {
+		return ""
+	}
+	path, _, _ := strings.Cut(strings.TrimPrefix(s.dsn, "file:"), "?")
+	return path
 }
 
 // CreateApp creates a new job application in the database
-func CreateApp(app *models.JobApplication) error {
-	result := db.Create(app)
+func (s *Store) CreateApp(app *models.JobApplication) error {
+	result := s.db.Create(app)
+	if isUniqueViolation(result.Error) {
+		return s.duplicateError(app)
+	}
 	if result.Error != nil {
 		return fmt.Errorf("failed to create app: %v", result.Error)
 	}
 	return nil
 }
 
-// GetAllApps retrieves all job applications from the database
-func GetAllApps() ([]models.JobApplication, error) {
+// ListApps retrieves every job application without its description, for
+// comparing against a newly parsed one
+func (s *Store) ListApps() ([]models.JobApplication, error) {
 	var apps []models.JobApplication
-	result := db.Order("date_applied DESC").Find(&apps).Limit(20)
+	result := s.db.Omit("description").Find(&apps)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list apps: %v", result.Error)
+	}
+	return apps, nil
+}
+
+// GetAllApps retrieves the most recently applied to job applications
+func (s *Store) GetAllApps() ([]models.JobApplication, error) {
+	var apps []models.JobApplication
+	result := s.db.Order("date_applied DESC").Limit(DefaultLimit).Find(&apps)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get apps: %v", result.Error)
 	}
@@ -70,9 +140,9 @@ func GetAllApps() ([]models.JobApplication, error) {
 }
 
 // GetAppByID retrieves a job application by ID
-func GetAppByID(id uint) (*models.JobApplication, error) {
+func (s *Store) GetAppByID(id uint) (*models.JobApplication, error) {
 	var app models.JobApplication
-	result := db.First(&app, id)
+	result := s.db.First(&app, id)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get app: %v", result.Error)
 	}
@@ -80,59 +150,215 @@ func GetAppByID(id uint) (*models.JobApplication, error) {
 }
 
 // UpdateApp updates a job application
-func UpdateApp(app *models.JobApplication) error {
-	result := db.Save(app)
+func (s *Store) UpdateApp(app *models.JobApplication) error {
+	result := s.db.Save(app)
+	if isUniqueViolation(result.Error) {
+		return s.duplicateError(app)
+	}
 	if result.Error != nil {
 		return fmt.Errorf("failed to update app: %v", result.Error)
 	}
 	return nil
 }
 
-// DeleteApp deletes a job application
-func DeleteApp(id uint) error {
-	result := db.Delete(&models.JobApplication{}, id)
+// DeleteApp moves a job application to the trash. Its status timeline is
+// kept so the application can be restored.
+func (s *Store) DeleteApp(id uint) error {
+	result := s.db.Delete(&models.JobApplication{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete app: %v", result.Error)
 	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to delete app: %v", gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// GetTrash retrieves the deleted job applications, most recently deleted first
+func (s *Store) GetTrash() ([]models.JobApplication, error) {
+	var apps []models.JobApplication
+	result := s.db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&apps)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get trash: %v", result.Error)
+	}
+	return apps, nil
+}
+
+// RestoreApp takes a job application out of the trash. It fails with
+// ErrDuplicateApp when the same application was saved again in the meantime.
+func (s *Store) RestoreApp(id uint) error {
+	var app models.JobApplication
+	if err := s.db.Unscoped().Where("deleted_at IS NOT NULL").First(&app, id).Error; err != nil {
+		return fmt.Errorf("failed to restore app: %v", err)
+	}
+
+	result := s.db.Unscoped().Model(&models.JobApplication{}).Where("app_id = ?", id).Update("deleted_at", nil)
+	if isUniqueViolation(result.Error) {
+		return s.duplicateError(&app)
+	}
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore app: %v", result.Error)
+	}
+	return nil
+}
+
+// PurgeApp permanently deletes a job application in the trash, its status
+// timeline and the operations that could undo it
+func (s *Store) PurgeApp(id uint) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("deleted_at IS NOT NULL").Delete(&models.JobApplication{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("app_id = ?", id).Delete(&models.Operation{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("app_id = ?", id).Delete(&models.StatusEvent{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to purge app: %v", err)
+	}
 	return nil
 }
 
-// createFTSTable creates the FTS5 virtual table for full-text search
-func createFTSTable() error {
-	// Create FTS5 virtual table
-	sql := `CREATE VIRTUAL TABLE IF NOT EXISTS apps_fts USING fts5(
-		company, 
-		position, 
-		notes, 
-		content='apps', 
-		content_rowid='app_id'
-	)`
+// PurgeDeletedApps permanently deletes the job applications moved to the
+// trash before cutoff, with their status timelines and operations. It
+// returns how many applications were purged.
+func (s *Store) PurgeDeletedApps(cutoff time.Time) (int64, error) {
+	var purged int64
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		expired := tx.Unscoped().Model(&models.JobApplication{}).Select("app_id").Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff)
+		if err := tx.Where("app_id IN (?)", expired).Delete(&models.StatusEvent{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("app_id IN (?)", expired).Delete(&models.Operation{}).Error; err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Delete(&models.JobApplication{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge trash: %v", err)
+	}
+	return purged, nil
+}
+
+// UpdateAppStatus changes an application's status and records the change in
+// status_events. It returns nil without writing anything when the status is unchanged.
+func (s *Store) UpdateAppStatus(id uint, status models.Status, note string) (*models.StatusEvent, error) {
+	var event *models.StatusEvent
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var app models.JobApplication
+		if err := tx.Select("app_id", "status").First(&app, id).Error; err != nil {
+			return err
+		}
+		if app.Status == status {
+			return nil
+		}
 
-	result := db.Exec(sql)
-	return result.Error
+		if err := tx.Model(&models.JobApplication{}).Where("app_id = ?", id).Update("status", status).Error; err != nil {
+			return err
+		}
+		event = &models.StatusEvent{AppId: id, FromStatus: app.Status, ToStatus: status, ChangedAt: time.Now(), Note: note}
+		return tx.Create(event).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update status: %v", err)
+	}
+	return event, nil
 }
 
-// SearchApps performs full-text search on job applications
-func SearchApps(query string) ([]models.JobApplication, error) {
-	var apps []models.JobApplication
+// CreateStatusEvent records a status change
+func (s *Store) CreateStatusEvent(event *models.StatusEvent) error {
+	result := s.db.Create(event)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create status event: %v", result.Error)
+	}
+	return nil
+}
+
+// GetStatusEvents retrieves the status changes of an application, oldest first
+func (s *Store) GetStatusEvents(appId uint) ([]models.StatusEvent, error) {
+	var events []models.StatusEvent
+	result := s.db.Where("app_id = ?", appId).Order("changed_at, id").Find(&events)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get status events: %v", result.Error)
+	}
+	return events, nil
+}
+
+// duplicateError describes a unique constraint conflict, naming the saved
+// application it conflicts with
+func (s *Store) duplicateError(app *models.JobApplication) error {
+	var existing models.JobApplication
+	result := s.db.Select("app_id").
+		Where("company = ? AND position = ? AND date_applied = ? AND app_id != ?", app.Company, app.Position, app.DateApplied, app.AppId).
+		Limit(1).Find(&existing)
+	if result.Error == nil && existing.AppId != 0 {
+		return fmt.Errorf("%w: %s at %s on %s is application #%d", ErrDuplicateApp, app.Position, app.Company, app.DateApplied.Format("2006-01-02"), existing.AppId)
+	}
+	return fmt.Errorf("%w: %s at %s on %s", ErrDuplicateApp, app.Position, app.Company, app.DateApplied.Format("2006-01-02"))
+}
+
+// isUniqueViolation reports whether err comes from a UNIQUE constraint
+func isUniqueViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+// Snippet highlight markers. They are control characters so the frontend can
+// highlight matches without rendering captured HTML.
+const (
+	SnippetStart = "\x02"
+	SnippetEnd   = "\x03"
+)
+
+// SearchResult is a job application matched by full-text search
+type SearchResult struct {
+	models.JobApplication
+	// Snippet is the best matching passage, with each match between
+	// SnippetStart and SnippetEnd
+	Snippet string `json:"snippet"`
+}
+
+// SearchApps performs full-text search on the company, position, notes and
+// description of the job applications that are not in the trash, best match
+// first. query uses FTS5 syntax, e.g. "golang OR rust", "senior NOT manager",
+// "platform*" or company:acme.
+func (s *Store) SearchApps(query string) ([]SearchResult, error) {
+	var results []SearchResult
 
 	// Search using FTS5 and join with main table
-	sql := `SELECT a.* FROM apps a 
-			JOIN apps_fts fts ON a.appId = fts.rowid 
-			WHERE apps_fts MATCH ? 
+	sql := `SELECT a.*, snippet(apps_fts, -1, ?, ?, '…', 16) AS snippet
+			FROM apps_fts
+			JOIN apps a ON a.app_id = apps_fts.rowid
+			WHERE apps_fts MATCH ? AND a.deleted_at IS NULL
 			ORDER BY bm25(apps_fts)`
 
-	result := db.Raw(sql, query).Scan(&apps)
+	result := s.db.Raw(sql, SnippetStart, SnippetEnd, query).Scan(&results)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to search apps: %v", result.Error)
 	}
 
-	return apps, nil
+	return results, nil
+}
+
+// RebuildFTS reindexes every application that is not in the trash, for
+// databases whose full-text index fell out of sync with the apps table
+func (s *Store) RebuildFTS() error {
+	if err := rebuildFTS(s.db); err != nil {
+		return fmt.Errorf("failed to rebuild search index: %v", err)
+	}
+	return nil
 }
 
-func SearchByCompany(companyName string) ([]models.JobApplication, error) {
+// SearchByCompany retrieves up to 10 job applications whose company contains companyName
+func (s *Store) SearchByCompany(companyName string) ([]models.JobApplication, error) {
 	var apps []models.JobApplication
-	result := db.Where("LOWER(company) LIKE LOWER(?)", "%"+companyName+"%").Find(&apps).Limit(10)
+	result := s.db.Where("LOWER(company) LIKE LOWER(?)", "%"+companyName+"%").Limit(10).Find(&apps)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -0,0 +1,408 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"track-my-job-apps/internal/models"
+	"track-my-job-apps/internal/sourceurl"
+)
+
+// Migration is one versioned schema change. Pending migrations run in order,
+// each in its own transaction, and are recorded in the schema_version table.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(tx *gorm.DB) error
+}
+
+// migrations is the full schema history. Databases created before versioning
+// have no schema_version table and run every migration, so each step must
+// also work on a schema that already has some of its changes. Never edit a
+// released migration; add a new one.
+var migrations = []Migration{
+	{1, "create apps table", createAppsTable},
+	{2, "store dates applied as YYYY-MM-DD", migrateDateApplied},
+	{3, "add structured salary and job detail columns", addJobDetailColumns},
+	{4, "add job descriptions", addDescriptionColumn},
+	{5, "move source URLs out of the notes", migrateSourceURLs},
+	{6, "add status timeline", migrateStatuses},
+	{7, "add trash", addTrash},
+	{8, "add operations log", createOperationsTable},
+	{9, "create full-text search index", createFTSTable},
+	{10, "keep the full-text search index in sync", createFTSTriggers},
+	{11, "add tags", addTagsColumn},
+	{12, "add saved searches", createSavedSearchesTable},
+}
+
+// SchemaVersion returns the version of the last applied migration, 0 for a
+// new database or one created before versioning
+func (s *Store) SchemaVersion() (int, error) {
+	return schemaVersion(s.db)
+}
+
+// Migrate applies the pending migrations and returns them. A copy of the
+// database file is written first when it already holds data and is not in
+// memory. With dryRun the pending migrations run in a transaction that is
+// rolled back, so nothing changes and no copy is written.
+func (s *Store) Migrate(dryRun bool) ([]Migration, error) {
+	if !dryRun {
+		if err := createSchemaVersionTable(s.db); err != nil {
+			return nil, fmt.Errorf("failed to create schema_version table: %v", err)
+		}
+	}
+	current, err := schemaVersion(s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range migrations {
+		if migration.Version > current {
+			pending = append(pending, migration)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	if dryRun {
+		return pending, s.dryRunMigrations(pending)
+	}
+
+	if s.Path() != "" && s.db.Migrator().HasTable("apps") {
+		backupPath := fmt.Sprintf("%s.v%d-%s.bak", s.Path(), current, time.Now().Format("20060102-150405"))
+		if err := s.db.Exec("VACUUM INTO ?", backupPath).Error; err != nil {
+			return nil, fmt.Errorf("failed to copy database before migrating: %v", err)
+		}
+		log.Printf("Copied database to %s before migrating", backupPath)
+	}
+
+	for _, migration := range pending {
+		log.Printf("Applying migration %d: %s", migration.Version, migration.Description)
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Exec("INSERT INTO schema_version (version, description, applied_at) VALUES (?, ?, ?)",
+				migration.Version, migration.Description, time.Now()).Error
+		})
+		if err != nil {
+			return nil, fmt.Errorf("migration %d (%s) failed: %v", migration.Version, migration.Description, err)
+		}
+	}
+	return pending, nil
+}
+
+// dryRunMigrations runs the pending migrations in one transaction and rolls it back
+func (s *Store) dryRunMigrations(pending []Migration) error {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return fmt.Errorf("failed to start dry run: %v", tx.Error)
+	}
+	defer tx.Rollback()
+
+	for _, migration := range pending {
+		if err := migration.Up(tx); err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %v", migration.Version, migration.Description, err)
+		}
+	}
+	return nil
+}
+
+func createSchemaVersionTable(tx *gorm.DB) error {
+	return tx.Exec(`CREATE TABLE IF NOT EXISTS schema_version (
+		version integer PRIMARY KEY,
+		description text,
+		applied_at datetime NOT NULL
+	)`).Error
+}
+
+func schemaVersion(tx *gorm.DB) (int, error) {
+	if !tx.Migrator().HasTable("schema_version") {
+		return 0, nil
+	}
+	var version int
+	if err := tx.Raw("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version).Error; err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %v", err)
+	}
+	return version, nil
+}
+
+// addColumn adds a column unless the table already has it
+func addColumn(tx *gorm.DB, table string, column string, definition string) error {
+	var count int
+	if err := tx.Raw("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column).Scan(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	return tx.Exec(fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN `%s` %s", table, column, definition)).Error
+}
+
+// execAll runs the statements in order, stopping at the first error
+func execAll(tx *gorm.DB, statements ...string) error {
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createAppsTable creates the apps table as the first release defined it
+func createAppsTable(tx *gorm.DB) error {
+	return execAll(tx,
+		"CREATE TABLE IF NOT EXISTS `apps` ("+
+			"`app_id` integer PRIMARY KEY AUTOINCREMENT,"+
+			"`company` varchar(255) NOT NULL,"+
+			"`position` varchar(255) NOT NULL,"+
+			"`location` varchar(255),"+
+			"`salary_range` varchar(100),"+
+			"`workplace_type` varchar(50),"+
+			"`status` varchar(50) DEFAULT 'SUBMITTED',"+
+			"`notes` text,"+
+			"`website` varchar(500),"+
+			"`date_applied` varchar(10))",
+		"CREATE UNIQUE INDEX IF NOT EXISTS `idx_company_position_date` ON `apps`(`company`,`position`,`date_applied`)",
+	)
+}
+
+// migrateDateApplied trims dates stored as timestamps by releases that
+// saved DateApplied as a datetime down to YYYY-MM-DD
+func migrateDateApplied(tx *gorm.DB) error {
+	return tx.Exec("UPDATE apps SET date_applied = SUBSTR(date_applied, 1, 10) WHERE LENGTH(date_applied) > 10").Error
+}
+
+// addJobDetailColumns adds the structured salary, employment type,
+// department, date posted and requisition ID columns
+func addJobDetailColumns(tx *gorm.DB) error {
+	columns := [][2]string{
+		{"salary_min", "real"},
+		{"salary_max", "real"},
+		{"salary_currency", "varchar(3)"},
+		{"salary_period", "varchar(20)"},
+		{"employment_type", "varchar(50)"},
+		{"department", "varchar(255)"},
+		{"date_posted", "varchar(10)"},
+		{"requisition_id", "varchar(100)"},
+	}
+	for _, column := range columns {
+		if err := addColumn(tx, "apps", column[0], column[1]); err != nil {
+			return err
+		}
+	}
+	return execAll(tx,
+		"CREATE INDEX IF NOT EXISTS `idx_apps_salary_min` ON `apps`(`salary_min`)",
+		"CREATE INDEX IF NOT EXISTS `idx_apps_salary_max` ON `apps`(`salary_max`)",
+	)
+}
+
+func addDescriptionColumn(tx *gorm.DB) error {
+	return addColumn(tx, "apps", "description", "text")
+}
+
+// migrateSourceURLs adds the source URL columns and moves the "Source URL: ..."
+// lines that parsers used to append to the notes into them
+func migrateSourceURLs(tx *gorm.DB) error {
+	for _, column := range []string{"source_url", "canonical_url"} {
+		if err := addColumn(tx, "apps", column, "varchar(1000)"); err != nil {
+			return err
+		}
+	}
+	if err := addColumn(tx, "apps", "board_job_id", "varchar(100)"); err != nil {
+		return err
+	}
+	err := execAll(tx,
+		"CREATE INDEX IF NOT EXISTS `idx_apps_canonical_url` ON `apps`(`canonical_url`)",
+		"CREATE INDEX IF NOT EXISTS `idx_apps_board_job_id` ON `apps`(`board_job_id`)",
+	)
+	if err != nil {
+		return err
+	}
+
+	var apps []struct {
+		AppId     uint
+		Notes     string
+		SourceURL string `gorm:"column:source_url"`
+	}
+	if err := tx.Raw("SELECT app_id, notes, COALESCE(source_url, '') AS source_url FROM apps WHERE notes LIKE ?", "%Source URL:%").Scan(&apps).Error; err != nil {
+		return err
+	}
+
+	for _, app := range apps {
+		var notes []string
+		sourceURL := app.SourceURL
+		for _, line := range strings.Split(app.Notes, "\n") {
+			if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "Source URL:"); ok {
+				if sourceURL == "" {
+					sourceURL = strings.TrimSpace(rest)
+				}
+				continue
+			}
+			notes = append(notes, line)
+		}
+
+		err := tx.Exec("UPDATE apps SET notes = ?, source_url = ?, canonical_url = ?, board_job_id = ? WHERE app_id = ?",
+			strings.TrimSpace(strings.Join(notes, "\n")), sourceURL, sourceurl.Canonical(sourceURL), sourceurl.JobID(sourceURL), app.AppId).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(apps) > 0 {
+		log.Printf("Moved source URLs out of the notes of %d applications", len(apps))
+	}
+	return nil
+}
+
+// migrateStatuses creates status_events, gives applications saved without a
+// status SUBMITTED, turns hand-edited values like "phone screen" into
+// PHONE_SCREEN and records an initial status event for every application
+func migrateStatuses(tx *gorm.DB) error {
+	return execAll(tx,
+		"CREATE TABLE IF NOT EXISTS `status_events` ("+
+			"`id` integer PRIMARY KEY AUTOINCREMENT,"+
+			"`app_id` integer NOT NULL,"+
+			"`from_status` varchar(50),"+
+			"`to_status` varchar(50) NOT NULL,"+
+			"`changed_at` datetime NOT NULL,"+
+			"`note` text)",
+		"CREATE INDEX IF NOT EXISTS `idx_status_events_app_id` ON `status_events`(`app_id`)",
+		"CREATE INDEX IF NOT EXISTS `idx_status_events_changed_at` ON `status_events`(`changed_at`)",
+		"UPDATE apps SET status = '"+string(models.SUBMITTED)+"' WHERE status IS NULL OR TRIM(status) = ''",
+		`UPDATE apps SET status = UPPER(REPLACE(REPLACE(TRIM(status), ' ', '_'), '-', '_'))
+			WHERE status != UPPER(REPLACE(REPLACE(TRIM(status), ' ', '_'), '-', '_'))`,
+		// Older applications start their timeline on the day they were applied to
+		`INSERT INTO status_events (app_id, from_status, to_status, changed_at, note)
+			SELECT app_id, '', status, COALESCE(date_applied, CURRENT_TIMESTAMP), 'Status before history was recorded'
+			FROM apps WHERE app_id NOT IN (SELECT app_id FROM status_events)`,
+	)
+}
+
+// addTrash adds the deleted_at tombstone and recreates the unique index so
+// it leaves trashed applications out
+func addTrash(tx *gorm.DB) error {
+	if err := addColumn(tx, "apps", "deleted_at", "datetime"); err != nil {
+		return err
+	}
+	return execAll(tx,
+		"CREATE INDEX IF NOT EXISTS `idx_apps_deleted_at` ON `apps`(`deleted_at`)",
+		"DROP INDEX IF EXISTS `idx_company_position_date`",
+		"CREATE UNIQUE INDEX `idx_company_position_date` ON `apps`(`company`,`position`,`date_applied`) WHERE deleted_at IS NULL",
+	)
+}
+
+func createOperationsTable(tx *gorm.DB) error {
+	return execAll(tx,
+		"CREATE TABLE IF NOT EXISTS `operations` ("+
+			"`id` integer PRIMARY KEY AUTOINCREMENT,"+
+			"`kind` varchar(20) NOT NULL,"+
+			"`app_id` integer NOT NULL,"+
+			"`before` text,"+
+			"`after` text,"+
+			"`summary` varchar(500),"+
+			"`undone` numeric NOT NULL DEFAULT 0,"+
+			"`created_at` datetime NOT NULL)",
+		"CREATE INDEX IF NOT EXISTS `idx_operations_app_id` ON `operations`(`app_id`)",
+	)
+}
+
+// createFTSTable creates the FTS5 virtual table for full-text search over
+// the applications that are not in the trash. A table from an older release
+// is dropped and rebuilt.
+func createFTSTable(tx *gorm.DB) error {
+	return execAll(tx,
+		"DROP TABLE IF EXISTS apps_fts",
+		`CREATE VIRTUAL TABLE apps_fts USING fts5(
+			company,
+			position,
+			notes,
+			description,
+			content='apps',
+			content_rowid='app_id'
+		)`,
+		`INSERT INTO apps_fts(rowid, company, position, notes, description)
+			SELECT app_id, company, position, notes, description FROM apps WHERE deleted_at IS NULL`,
+	)
+}
+
+// createFTSTriggers adds the triggers that index inserted, updated and
+// restored applications and drop deleted and trashed ones from apps_fts.
+// Applications saved before the triggers existed are indexed again.
+func createFTSTriggers(tx *gorm.DB) error {
+	err := execAll(tx,
+		`CREATE TRIGGER IF NOT EXISTS apps_fts_insert AFTER INSERT ON apps WHEN new.deleted_at IS NULL BEGIN
+			INSERT INTO apps_fts(rowid, company, position, notes, description)
+			VALUES (new.app_id, new.company, new.position, new.notes, new.description);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS apps_fts_delete AFTER DELETE ON apps WHEN old.deleted_at IS NULL BEGIN
+			INSERT INTO apps_fts(apps_fts, rowid, company, position, notes, description)
+			VALUES ('delete', old.app_id, old.company, old.position, old.notes, old.description);
+		END`,
+		// Moving to and out of the trash is an update of deleted_at
+		`CREATE TRIGGER IF NOT EXISTS apps_fts_update AFTER UPDATE ON apps BEGIN
+			INSERT INTO apps_fts(apps_fts, rowid, company, position, notes, description)
+			SELECT 'delete', old.app_id, old.company, old.position, old.notes, old.description WHERE old.deleted_at IS NULL;
+			INSERT INTO apps_fts(rowid, company, position, notes, description)
+			SELECT new.app_id, new.company, new.position, new.notes, new.description WHERE new.deleted_at IS NULL;
+		END`,
+	)
+	if err != nil {
+		return err
+	}
+	return rebuildFTS(tx)
+}
+
+// rebuildFTS clears apps_fts and indexes every application not in the trash
+func rebuildFTS(tx *gorm.DB) error {
+	return execAll(tx,
+		"INSERT INTO apps_fts(apps_fts) VALUES('delete-all')",
+		`INSERT INTO apps_fts(rowid, company, position, notes, description)
+			SELECT app_id, company, position, notes, description FROM apps WHERE deleted_at IS NULL`,
+	)
+}
+
+func addTagsColumn(tx *gorm.DB) error {
+	return addColumn(tx, "apps", "tags", "text")
+}
+
+// createSavedSearchesTable creates the saved searches table and pins a few
+// example views to start from
+func createSavedSearchesTable(tx *gorm.DB) error {
+	err := execAll(tx,
+		"CREATE TABLE IF NOT EXISTS `saved_searches` ("+
+			"`id` integer PRIMARY KEY AUTOINCREMENT,"+
+			"`name` varchar(255) NOT NULL,"+
+			"`query` text,"+
+			"`pinned` numeric NOT NULL DEFAULT false,"+
+			"`position` integer NOT NULL DEFAULT 0,"+
+			"`created_at` datetime,"+
+			"`updated_at` datetime)",
+		"CREATE UNIQUE INDEX IF NOT EXISTS `idx_saved_searches_name` ON `saved_searches`(`name`)",
+	)
+	if err != nil {
+		return err
+	}
+
+	// Raw JSON so the seed does not change with the Query type
+	now := time.Now()
+	examples := [][2]string{
+		{"Active interviews", `{"statuses":["PHONE_SCREEN","TAKE_HOME","REMOTE_INTERVIEW","ON_SITE_INTERVIEW"]}`},
+		{"No response after 3 weeks", `{"statuses":["SUBMITTED"],"appliedMoreThanDaysAgo":21}`},
+		{"Remote, over 150K", `{"workplaceTypes":["Remote"],"salaryMin":150000}`},
+	}
+	for i, example := range examples {
+		err := tx.Exec("INSERT OR IGNORE INTO saved_searches (name, query, pinned, position, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)",
+			example[0], example[1], true, i, now, now).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
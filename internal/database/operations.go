@@ -0,0 +1,149 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"track-my-job-apps/internal/models"
+)
+
+// RecordOperation logs a change so it can be undone. Operations undone
+// before it can no longer be redone, and only the newest depth operations
+// are kept. before and after are the application around an UPDATE; the
+// other kinds only need after.
+func (s *Store) RecordOperation(kind models.OperationKind, before *models.JobApplication, after *models.JobApplication, summary string, depth int) error {
+	op := &models.Operation{Kind: kind, AppId: after.AppId, Summary: summary, CreatedAt: time.Now()}
+	if kind == models.UPDATE {
+		beforeJSON, err := json.Marshal(before)
+		if err != nil {
+			return fmt.Errorf("failed to record operation: %v", err)
+		}
+		afterJSON, err := json.Marshal(after)
+		if err != nil {
+			return fmt.Errorf("failed to record operation: %v", err)
+		}
+		op.Before, op.After = string(beforeJSON), string(afterJSON)
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("undone = ?", true).Delete(&models.Operation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(op).Error; err != nil {
+			return err
+		}
+		return tx.Exec("DELETE FROM operations WHERE id NOT IN (SELECT id FROM operations ORDER BY id DESC LIMIT ?)", depth).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record operation: %v", err)
+	}
+	return nil
+}
+
+// UndoOperation reverts the most recent operation that has not been undone
+// and returns it, or nil when there is nothing to undo
+func (s *Store) UndoOperation() (*models.Operation, error) {
+	return s.replayOperation(true)
+}
+
+// RedoOperation applies the operation undone last again and returns it, or
+// nil when there is nothing to redo
+func (s *Store) RedoOperation() (*models.Operation, error) {
+	return s.replayOperation(false)
+}
+
+// replayOperation reverts (undo) or reapplies (redo) an operation and flips its undone flag
+func (s *Store) replayOperation(undo bool) (*models.Operation, error) {
+	var op models.Operation
+	query := s.db.Where("undone = ?", !undo)
+	if undo {
+		query = query.Order("id DESC")
+	} else {
+		query = query.Order("id")
+	}
+	result := query.Limit(1).Find(&op)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get operation: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	var conflict *models.JobApplication
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		switch {
+		case op.Kind == models.UPDATE:
+			snapshot := op.After
+			if undo {
+				snapshot = op.Before
+			}
+			conflict, err = applySnapshot(tx, snapshot, undo)
+		case (op.Kind == models.CREATE || op.Kind == models.RESTORE) == undo:
+			// Undoing a create or restore, or redoing a delete, moves the app back to the trash
+			err = tx.Delete(&models.JobApplication{}, op.AppId).Error
+		default:
+			conflict, err = restoreInTx(tx, op.AppId)
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&op).Update("undone", undo).Error
+	})
+	if conflict != nil {
+		return nil, s.duplicateError(conflict)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay operation: %v", err)
+	}
+	return &op, nil
+}
+
+// applySnapshot writes a JSON snapshot of an application back, recording a
+// status event when it changes the status. It returns the snapshot when it
+// conflicts with another saved application.
+func applySnapshot(tx *gorm.DB, snapshot string, undo bool) (*models.JobApplication, error) {
+	var app models.JobApplication
+	if err := json.Unmarshal([]byte(snapshot), &app); err != nil {
+		return nil, err
+	}
+	var current models.JobApplication
+	if err := tx.Select("app_id", "status").First(&current, app.AppId).Error; err != nil {
+		return nil, err
+	}
+
+	err := tx.Save(&app).Error
+	if isUniqueViolation(err) {
+		return &app, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Status != app.Status {
+		note := "Redo"
+		if undo {
+			note = "Undo"
+		}
+		event := &models.StatusEvent{AppId: app.AppId, FromStatus: current.Status, ToStatus: app.Status, ChangedAt: time.Now(), Note: note}
+		return nil, tx.Create(event).Error
+	}
+	return nil, nil
+}
+
+// restoreInTx takes an application out of the trash, returning it when it
+// conflicts with another saved application
+func restoreInTx(tx *gorm.DB, id uint) (*models.JobApplication, error) {
+	var app models.JobApplication
+	if err := tx.Unscoped().First(&app, id).Error; err != nil {
+		return nil, err
+	}
+	err := tx.Unscoped().Model(&models.JobApplication{}).Where("app_id = ?", id).Update("deleted_at", nil).Error
+	if isUniqueViolation(err) {
+		return &app, err
+	}
+	return nil, err
+}
@@ -0,0 +1,202 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"track-my-job-apps/internal/models"
+)
+
+const (
+	// DefaultLimit is the page size when a query sets none
+	DefaultLimit = 20
+	// MaxLimit is the largest page a query returns
+	MaxLimit = 500
+)
+
+// sortColumns maps the sortable fields to their columns. "salary" sorts by
+// the annualized salary and needs Query.AnnualSalarySQL.
+var sortColumns = map[string]string{
+	"appId":         "app_id",
+	"company":       "company",
+	"position":      "position",
+	"location":      "location",
+	"status":        "status",
+	"workplaceType": "workplace_type",
+	"dateApplied":   "date_applied",
+	"datePosted":    "date_posted",
+	"salary":        "",
+}
+
+// Query selects job applications that are not in the trash. Every filter is
+// optional and filters combine with AND; text filters match any part of the
+// value, ignoring case.
+type Query struct {
+	Statuses       []models.Status `json:"statuses"`
+	Company        string          `json:"company"`
+	Position       string          `json:"position"`
+	Location       string          `json:"location"`
+	WorkplaceTypes []string        `json:"workplaceTypes"`
+	// AppliedFrom and AppliedTo bound the date applied, both inclusive
+	AppliedFrom models.DateOnly `json:"appliedFrom"`
+	AppliedTo   models.DateOnly `json:"appliedTo"`
+	// AppliedWithinDays and AppliedMoreThanDaysAgo bound the date applied
+	// relative to today, so saved searches stay current
+	AppliedWithinDays      int `json:"appliedWithinDays"`
+	AppliedMoreThanDaysAgo int `json:"appliedMoreThanDaysAgo"`
+	// SalaryMin and SalaryMax bound the annualized salary in the home currency
+	SalaryMin float64 `json:"salaryMin"`
+	SalaryMax float64 `json:"salaryMax"`
+	// Tags lists tags an application must all have
+	Tags []string `json:"tags"`
+	// Sort orders by each field in turn, newest applications first when empty
+	Sort []SortField `json:"sort"`
+	// Limit is the page size, DefaultLimit when zero; Offset skips that many matches
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+
+	// AnnualSalarySQL computes the annualized salary of a row, for the salary
+	// filter and sort. See salary.AnnualSQL.
+	AnnualSalarySQL string `json:"-"`
+}
+
+// SortField is one field of a Query's sort order
+type SortField struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc"`
+}
+
+// Page is one page of query results
+type Page struct {
+	Apps []models.JobApplication `json:"apps"`
+	// Total is the number of matches across all pages
+	Total  int64 `json:"total"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+// QueryApps returns the page of job applications matching q and the total
+// number of matches
+func (s *Store) QueryApps(q Query) (*Page, error) {
+	order, err := q.order()
+	if err != nil {
+		return nil, err
+	}
+	if err := q.validateSalary(); err != nil {
+		return nil, err
+	}
+
+	page := &Page{Apps: []models.JobApplication{}, Offset: max(q.Offset, 0), Limit: q.Limit}
+	if page.Limit <= 0 {
+		page.Limit = DefaultLimit
+	}
+	page.Limit = min(page.Limit, MaxLimit)
+
+	if err := q.filter(s.db.Model(&models.JobApplication{})).Count(&page.Total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count apps: %v", err)
+	}
+
+	tx := q.filter(s.db)
+	for _, clause := range order {
+		tx = tx.Order(clause)
+	}
+	result := tx.Limit(page.Limit).Offset(page.Offset).Find(&page.Apps)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query apps: %v", result.Error)
+	}
+	return page, nil
+}
+
+// CountApps returns the number of job applications matching q's filters
+func (s *Store) CountApps(q Query) (int64, error) {
+	if err := q.validateSalary(); err != nil {
+		return 0, err
+	}
+	var total int64
+	if err := q.filter(s.db.Model(&models.JobApplication{})).Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to count apps: %v", err)
+	}
+	return total, nil
+}
+
+func (q Query) validateSalary() error {
+	if (q.SalaryMin > 0 || q.SalaryMax > 0) && q.AnnualSalarySQL == "" {
+		return fmt.Errorf("invalid query: the salary filter needs AnnualSalarySQL")
+	}
+	return nil
+}
+
+// filter adds q's filters to tx
+func (q Query) filter(tx *gorm.DB) *gorm.DB {
+	if len(q.Statuses) > 0 {
+		tx = tx.Where("status IN ?", q.Statuses)
+	}
+	for _, text := range [][2]string{{"company", q.Company}, {"position", q.Position}, {"location", q.Location}} {
+		if value := strings.TrimSpace(text[1]); value != "" {
+			tx = tx.Where("LOWER("+text[0]+") LIKE LOWER(?)", "%"+value+"%")
+		}
+	}
+	if len(q.WorkplaceTypes) > 0 {
+		var types []string
+		for _, workplaceType := range q.WorkplaceTypes {
+			types = append(types, strings.ToLower(workplaceType))
+		}
+		tx = tx.Where("LOWER(workplace_type) IN ?", types)
+	}
+	if !q.AppliedFrom.IsZero() {
+		tx = tx.Where("date_applied >= ?", q.AppliedFrom)
+	}
+	if !q.AppliedTo.IsZero() {
+		tx = tx.Where("date_applied <= ?", q.AppliedTo)
+	}
+	today := time.Now()
+	if q.AppliedWithinDays > 0 {
+		tx = tx.Where("date_applied >= ?", models.DateOnly{Time: today.AddDate(0, 0, -q.AppliedWithinDays)})
+	}
+	if q.AppliedMoreThanDaysAgo > 0 {
+		tx = tx.Where("date_applied < ?", models.DateOnly{Time: today.AddDate(0, 0, -q.AppliedMoreThanDaysAgo)})
+	}
+	if q.SalaryMin > 0 {
+		tx = tx.Where(q.AnnualSalarySQL+" >= ?", q.SalaryMin)
+	}
+	if q.SalaryMax > 0 {
+		tx = tx.Where(q.AnnualSalarySQL+" <= ?", q.SalaryMax)
+	}
+	for _, tag := range models.NormalizeTags(q.Tags) {
+		tx = tx.Where("INSTR(',' || COALESCE(tags, '') || ',', ?) > 0", ","+tag+",")
+	}
+	return tx
+}
+
+// order returns the ORDER BY clauses for q.Sort, ending with app_id so pages
+// never overlap
+func (q Query) order() ([]string, error) {
+	sortFields := q.Sort
+	if len(sortFields) == 0 {
+		sortFields = []SortField{{Field: "dateApplied", Desc: true}}
+	}
+
+	var clauses []string
+	for _, field := range sortFields {
+		column, ok := sortColumns[field.Field]
+		if !ok {
+			return nil, fmt.Errorf("invalid query: cannot sort by %q", field.Field)
+		}
+		if field.Field == "salary" {
+			if q.AnnualSalarySQL == "" {
+				return nil, fmt.Errorf("invalid query: sorting by salary needs AnnualSalarySQL")
+			}
+			column = q.AnnualSalarySQL
+		}
+		direction := "ASC"
+		if field.Desc {
+			direction = "DESC"
+		}
+		// Applications without a value go last either way
+		clauses = append(clauses, column+" "+direction+" NULLS LAST")
+	}
+	return append(clauses, "app_id DESC"), nil
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SavedSearch is a named Query, shown as a view in the search screen.
+// Pinned searches are listed first, in Position order.
+type SavedSearch struct {
+	Id       uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name     string `gorm:"type:varchar(255);not null;uniqueIndex" json:"name"`
+	Query    Query  `gorm:"type:text;serializer:json" json:"query"`
+	Pinned   bool   `gorm:"not null;default:false" json:"pinned"`
+	Position int    `gorm:"not null;default:0" json:"position"`
+	// Count is the number of applications the search matches, computed on read
+	Count     int64     `gorm:"-" json:"count"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM
+func (SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+// ListSavedSearches retrieves the saved searches, pinned ones first
+func (s *Store) ListSavedSearches() ([]SavedSearch, error) {
+	var searches []SavedSearch
+	result := s.db.Order("pinned DESC, position, name").Find(&searches)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %v", result.Error)
+	}
+	return searches, nil
+}
+
+// GetSavedSearch retrieves a saved search by ID
+func (s *Store) GetSavedSearch(id uint) (*SavedSearch, error) {
+	var search SavedSearch
+	result := s.db.First(&search, id)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get saved search: %v", result.Error)
+	}
+	return &search, nil
+}
+
+// SaveSearch creates a saved search, or updates it when it has an ID. Names
+// must be unique.
+func (s *Store) SaveSearch(search *SavedSearch) error {
+	search.Name = strings.TrimSpace(search.Name)
+	if search.Name == "" {
+		return fmt.Errorf("failed to save search: a name is required")
+	}
+	search.Query.AnnualSalarySQL = ""
+
+	result := s.db.Save(search)
+	if isUniqueViolation(result.Error) {
+		return fmt.Errorf("failed to save search: a search named %q already exists", search.Name)
+	}
+	if result.Error != nil {
+		return fmt.Errorf("failed to save search: %v", result.Error)
+	}
+	return nil
+}
+
+// DeleteSavedSearch deletes a saved search
+func (s *Store) DeleteSavedSearch(id uint) error {
+	result := s.db.Delete(&SavedSearch{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete saved search: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to delete saved search: %v", gorm.ErrRecordNotFound)
+	}
+	return nil
+}
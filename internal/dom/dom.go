@@ -0,0 +1,164 @@
+// Package dom is a small selector-based extraction layer over golang.org/x/net/html.
+//
+// Selectors support the CSS subset job board parsers need: type, #id, .class
+// and [attr], [attr=v], [attr~=v], [attr^=v], [attr$=v], [attr*=v] attribute
+// tests, compounded freely and combined with descendant (space) and child (>)
+// combinators. Comma separated groups match any of their selectors.
+package dom
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Node is an element or document in a parsed HTML tree. Methods are nil-safe
+// so lookups can be chained without checking every step.
+type Node struct {
+	*html.Node
+}
+
+// Parse parses an HTML document or fragment
+func Parse(content string) (*Node, error) {
+	root, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html: %v", err)
+	}
+	return &Node{root}, nil
+}
+
+// Find returns every descendant matching the selector, in document order
+func (n *Node) Find(selector string) []*Node {
+	if n == nil {
+		return nil
+	}
+
+	groups, err := compile(selector)
+	if err != nil {
+		return nil
+	}
+
+	var found []*Node
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.ElementNode && groups.matches(child) {
+				found = append(found, &Node{child})
+			}
+			walk(child)
+		}
+	}
+	walk(n.Node)
+	return found
+}
+
+// First returns the first descendant matching the selector, or nil
+func (n *Node) First(selector string) *Node {
+	if found := n.Find(selector); len(found) > 0 {
+		return found[0]
+	}
+	return nil
+}
+
+// ParentElement returns the element enclosing n, or nil
+func (n *Node) ParentElement() *Node {
+	if n == nil || n.Node.Parent == nil || n.Node.Parent.Type != html.ElementNode {
+		return nil
+	}
+	return &Node{n.Node.Parent}
+}
+
+// Attr returns the value of an attribute, or "" when it is not set
+func (n *Node) Attr(name string) string {
+	if n == nil {
+		return ""
+	}
+	return attr(n.Node, name)
+}
+
+// Text returns the decoded text content with whitespace collapsed.
+// Block elements are separated by a space; script and style content is skipped.
+func (n *Node) Text() string {
+	if n == nil {
+		return ""
+	}
+	var b strings.Builder
+	writeText(&b, n.Node, nil)
+	return NormalizeSpace(b.String())
+}
+
+// TextExcept is Text without the content of descendants matching the
+// selector, such as a "New" badge inside a title
+func (n *Node) TextExcept(selector string) string {
+	if n == nil {
+		return ""
+	}
+	skip, err := compile(selector)
+	if err != nil {
+		return n.Text()
+	}
+	var b strings.Builder
+	writeText(&b, n.Node, skip)
+	return NormalizeSpace(b.String())
+}
+
+// Texts returns the text of every node
+func Texts(nodes []*Node) []string {
+	var texts []string
+	for _, node := range nodes {
+		if text := node.Text(); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return texts
+}
+
+// NormalizeSpace collapses runs of whitespace, including non-breaking spaces, into single spaces
+func NormalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func writeText(b *strings.Builder, node *html.Node, skip selectorGroup) {
+	switch node.Type {
+	case html.TextNode:
+		b.WriteString(node.Data)
+		return
+	case html.CommentNode:
+		return
+	case html.ElementNode:
+		switch node.DataAtom {
+		case atom.Script, atom.Style, atom.Noscript, atom.Template:
+			return
+		}
+		if skip != nil && skip.matches(node) {
+			return
+		}
+	}
+
+	block := isBlock(node)
+	if block {
+		b.WriteByte(' ')
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeText(b, child, skip)
+	}
+	if block {
+		b.WriteByte(' ')
+	}
+}
+
+func isBlock(node *html.Node) bool {
+	if node.Type != html.ElementNode {
+		return false
+	}
+	switch node.DataAtom {
+	case atom.Address, atom.Article, atom.Aside, atom.Blockquote, atom.Br, atom.Dd, atom.Div,
+		atom.Dl, atom.Dt, atom.Footer, atom.Form, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5,
+		atom.H6, atom.Header, atom.Hr, atom.Li, atom.Main, atom.Nav, atom.Ol, atom.P, atom.Pre,
+		atom.Section, atom.Table, atom.Td, atom.Th, atom.Tr, atom.Ul:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package dom
+
+import (
+	"testing"
+)
+
+const testPage = `
+<main class="job-post">
+	<div class="image-container"><img src="logo.png" alt="Acme Logo"></div>
+	<div class="job__title">
+		<h1 class="section-header">Senior&nbsp;Engineer, <span>Platform</span></h1>
+		<div class="job__location"><svg></svg><div>Denver,
+			Colorado</div></div>
+	</div>
+	<div id="content" class="job__description body">
+		<p>We&#8217;re hiring &amp; growing.</p>
+		<ul><li>Go</li><li>SQL</li></ul>
+		<script>var ignored = true;</script>
+		<!-- comment -->
+	</div>
+	<a data-automation-id="jobLink" href="https://example.com/jobs/1?gh_jid=1">Apply</a>
+</main>`
+
+func TestFindAndText(t *testing.T) {
+	doc, err := Parse(testPage)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		selector string
+		expected string
+	}{
+		{".job__title h1", "Senior Engineer, Platform"},
+		{"div.job__title > h1.section-header", "Senior Engineer, Platform"},
+		{".job__location div", "Denver, Colorado"},
+		{"#content p", "We’re hiring & growing."},
+		{"#content", "We’re hiring & growing. Go SQL"},
+		{"[data-automation-id=jobLink]", "Apply"},
+		{`a[href*="gh_jid"]`, "Apply"},
+		{"main > h1, .job__title h1", "Senior Engineer, Platform"},
+	}
+
+	for _, test := range tests {
+		if got := doc.First(test.selector).Text(); got != test.expected {
+			t.Errorf("First(%q).Text() = %q, expected %q", test.selector, got, test.expected)
+		}
+	}
+}
+
+func TestAttrAndMissing(t *testing.T) {
+	doc, err := Parse(testPage)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if alt := doc.First(`img[alt$="Logo"]`).Attr("alt"); alt != "Acme Logo" {
+		t.Errorf("Expected alt 'Acme Logo', got '%s'", alt)
+	}
+	if got := doc.First(".missing h1").Text(); got != "" {
+		t.Errorf("Expected empty text for missing node, got '%s'", got)
+	}
+	if got := doc.First("main > h1"); got != nil {
+		t.Errorf("Expected child combinator not to match grandchildren")
+	}
+	if got := doc.First(".job__title h1").TextExcept("span"); got != "Senior Engineer," {
+		t.Errorf("Expected the span to be left out, got '%s'", got)
+	}
+	if class := doc.First(".job__location").ParentElement().Attr("class"); class != "job__title" {
+		t.Errorf("Expected the location's parent to be job__title, got '%s'", class)
+	}
+	if items := Texts(doc.Find("ul li")); len(items) != 2 || items[1] != "SQL" {
+		t.Errorf("Expected list items [Go SQL], got %v", items)
+	}
+	if found := doc.Find("div[unterminated"); found != nil {
+		t.Errorf("Expected invalid selector to match nothing, got %d nodes", len(found))
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	doc, err := Parse(`<div class="description">
+		<h2>About <b>Acme</b></h2>
+		<p>We build tools.<br>Remote friendly.</p>
+		<p>What you'll do:</p>
+		<ul><li><p>Ship Go services</p></li><li>Review code<ul><li>Mostly Go</li></ul></li></ul>
+		<script>ignored()</script>
+		<div>Equal opportunity employer.</div>
+	</div>`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	expected := "## About Acme\n\nWe build tools.\nRemote friendly.\n\nWhat you'll do:\n\n- Ship Go services\n- Review code\n  - Mostly Go\n\nEqual opportunity employer."
+	if got := doc.First(".description").Markdown(); got != expected {
+		t.Errorf("Markdown() = %q, expected %q", got, expected)
+	}
+	if got := doc.First(".missing").Markdown(); got != "" {
+		t.Errorf("Expected empty Markdown for missing node, got %q", got)
+	}
+}
@@ -0,0 +1,107 @@
+package dom
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Markdown renders the node as Markdown-ish plain text: headings become "#"
+// lines, list items "- " bullets, and paragraphs are separated by blank lines
+func (n *Node) Markdown() string {
+	if n == nil {
+		return ""
+	}
+	w := &markdownWriter{}
+	w.render(n.Node)
+	w.endLine()
+	return strings.Join(w.lines, "\n")
+}
+
+type markdownWriter struct {
+	lines     []string
+	current   strings.Builder
+	prefix    string
+	blank     bool // a blank line is pending before the next line
+	listDepth int
+}
+
+func (w *markdownWriter) render(node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		w.current.WriteString(node.Data)
+		return
+	case html.CommentNode:
+		return
+	case html.ElementNode:
+		switch node.DataAtom {
+		case atom.Script, atom.Style, atom.Noscript, atom.Template, atom.Svg, atom.Button:
+			return
+		case atom.Br:
+			w.endLine()
+			return
+		case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+			w.endBlock()
+			w.prefix = strings.Repeat("#", int(node.Data[1]-'0')) + " "
+			w.renderChildren(node)
+			w.endBlock()
+			return
+		case atom.Ul, atom.Ol:
+			w.startBlock()
+			w.listDepth++
+			w.renderChildren(node)
+			w.listDepth--
+			w.startBlock()
+			return
+		case atom.Li:
+			w.endLine()
+			w.prefix = strings.Repeat("  ", max(w.listDepth-1, 0)) + "- "
+			w.renderChildren(node)
+			w.endLine()
+			return
+		}
+	}
+
+	if isBlock(node) {
+		w.startBlock()
+		w.renderChildren(node)
+		w.startBlock()
+		return
+	}
+	w.renderChildren(node)
+}
+
+func (w *markdownWriter) renderChildren(node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		w.render(child)
+	}
+}
+
+// startBlock separates blocks with a blank line, except inside lists
+func (w *markdownWriter) startBlock() {
+	if w.listDepth > 0 {
+		w.endLine()
+		return
+	}
+	w.endBlock()
+}
+
+func (w *markdownWriter) endBlock() {
+	w.endLine()
+	w.blank = true
+}
+
+func (w *markdownWriter) endLine() {
+	text := NormalizeSpace(w.current.String())
+	w.current.Reset()
+	if text == "" {
+		return
+	}
+	if w.blank && len(w.lines) > 0 {
+		w.lines = append(w.lines, "")
+	}
+	w.blank = false
+	w.lines = append(w.lines, w.prefix+text)
+	w.prefix = ""
+}
@@ -0,0 +1,311 @@
+package dom
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// attrTest is one [name op value] test
+type attrTest struct {
+	name  string
+	op    string // "", "=", "~=", "^=", "$=", "*="
+	value string
+}
+
+// compound is a run of simple selectors that all apply to one element
+type compound struct {
+	tag        string
+	id         string
+	classes    []string
+	attrs      []attrTest
+	combinator byte // relation to the previous compound: ' ' descendant, '>' child
+}
+
+// selector is a chain of compounds read left to right
+type selector []compound
+
+// selectorGroup is a comma separated list of selectors
+type selectorGroup []selector
+
+func compile(source string) (selectorGroup, error) {
+	var group selectorGroup
+	for _, part := range splitTopLevel(source, ',') {
+		sel, err := compileSelector(part)
+		if err != nil {
+			return nil, err
+		}
+		group = append(group, sel)
+	}
+	if len(group) == 0 {
+		return nil, fmt.Errorf("empty selector")
+	}
+	return group, nil
+}
+
+func compileSelector(source string) (selector, error) {
+	var sel selector
+	combinator := byte(0)
+	s := strings.TrimSpace(source)
+
+	for len(s) > 0 {
+		switch {
+		case s[0] == ' ' || s[0] == '\t' || s[0] == '\n':
+			if combinator == 0 && len(sel) > 0 {
+				combinator = ' '
+			}
+			s = s[1:]
+			continue
+		case s[0] == '>':
+			if len(sel) == 0 {
+				return nil, fmt.Errorf("selector %q starts with a combinator", source)
+			}
+			combinator = '>'
+			s = s[1:]
+			continue
+		}
+
+		c, rest, err := compileCompound(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %v", source, err)
+		}
+		if len(sel) > 0 {
+			c.combinator = combinator
+		}
+		sel = append(sel, c)
+		combinator = 0
+		s = rest
+	}
+
+	if len(sel) == 0 {
+		return nil, fmt.Errorf("empty selector")
+	}
+	return sel, nil
+}
+
+func compileCompound(s string) (compound, string, error) {
+	var c compound
+	start := len(s)
+
+	for len(s) > 0 {
+		switch s[0] {
+		case '#':
+			name, rest := readIdent(s[1:])
+			if name == "" {
+				return c, s, fmt.Errorf("missing id after '#'")
+			}
+			c.id, s = name, rest
+		case '.':
+			name, rest := readIdent(s[1:])
+			if name == "" {
+				return c, s, fmt.Errorf("missing class after '.'")
+			}
+			c.classes, s = append(c.classes, name), rest
+		case '[':
+			test, rest, err := compileAttr(s[1:])
+			if err != nil {
+				return c, s, err
+			}
+			c.attrs, s = append(c.attrs, test), rest
+		case '*':
+			s = s[1:]
+		case ' ', '\t', '\n', '>':
+			if len(s) == start {
+				return c, s, fmt.Errorf("empty compound")
+			}
+			return c, s, nil
+		default:
+			if len(s) != start {
+				return c, s, fmt.Errorf("unexpected %q", s[0])
+			}
+			name, rest := readIdent(s)
+			if name == "" {
+				return c, s, fmt.Errorf("unexpected %q", s[0])
+			}
+			c.tag, s = strings.ToLower(name), rest
+		}
+	}
+	return c, s, nil
+}
+
+// compileAttr reads an attribute test from just after its '[': the name,
+// then an optional operator and value, then the closing ']'. It returns the
+// rest of the selector.
+func compileAttr(s string) (attrTest, string, error) {
+	var test attrTest
+	s = strings.TrimLeft(s, " \t")
+	test.name, s = readIdent(s)
+	if test.name == "" {
+		return test, s, fmt.Errorf("missing attribute name")
+	}
+	s = strings.TrimLeft(s, " \t")
+
+	for _, op := range []string{"~=", "^=", "$=", "*=", "="} {
+		if strings.HasPrefix(s, op) {
+			test.op, s = op, strings.TrimLeft(s[len(op):], " \t")
+			break
+		}
+	}
+	if test.op != "" {
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			end := strings.IndexByte(s[1:], s[0])
+			if end == -1 {
+				return test, s, fmt.Errorf("unterminated attribute value")
+			}
+			test.value, s = s[1:end+1], s[end+2:]
+		} else {
+			test.value, s = readIdent(s)
+		}
+		s = strings.TrimLeft(s, " \t")
+	}
+
+	if s == "" || s[0] != ']' {
+		return test, s, fmt.Errorf("unterminated attribute selector")
+	}
+	return test, s[1:], nil
+}
+
+func readIdent(s string) (string, string) {
+	i := 0
+	for i < len(s) {
+		ch := s[i]
+		if ch == '-' || ch == '_' || ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= 0x80 {
+			i++
+			continue
+		}
+		break
+	}
+	return s[:i], s[i:]
+}
+
+// splitTopLevel splits on sep outside of brackets and quotes
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth := 0
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '[':
+			depth++
+		case ch == ']':
+			depth--
+		case ch == sep && depth == 0:
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		parts = append(parts, last)
+	}
+	return parts
+}
+
+func (g selectorGroup) matches(node *html.Node) bool {
+	for _, sel := range g {
+		if sel.matchesAt(node, len(sel)-1) {
+			return true
+		}
+	}
+	return false
+}
+
+func (sel selector) matchesAt(node *html.Node, i int) bool {
+	if !sel[i].matches(node) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+
+	switch sel[i].combinator {
+	case '>':
+		parent := node.Parent
+		return parent != nil && parent.Type == html.ElementNode && sel.matchesAt(parent, i-1)
+	default:
+		for ancestor := node.Parent; ancestor != nil; ancestor = ancestor.Parent {
+			if ancestor.Type == html.ElementNode && sel.matchesAt(ancestor, i-1) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (c compound) matches(node *html.Node) bool {
+	if node.Type != html.ElementNode {
+		return false
+	}
+	if c.tag != "" && node.Data != c.tag {
+		return false
+	}
+	if c.id != "" && attr(node, "id") != c.id {
+		return false
+	}
+	if len(c.classes) > 0 {
+		classes := strings.Fields(attr(node, "class"))
+		for _, want := range c.classes {
+			if !contains(classes, want) {
+				return false
+			}
+		}
+	}
+	for _, test := range c.attrs {
+		if !test.matches(node) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t attrTest) matches(node *html.Node) bool {
+	value, ok := lookupAttr(node, t.name)
+	if !ok {
+		return false
+	}
+	switch t.op {
+	case "":
+		return true
+	case "=":
+		return value == t.value
+	case "~=":
+		return contains(strings.Fields(value), t.value)
+	case "^=":
+		return strings.HasPrefix(value, t.value)
+	case "$=":
+		return strings.HasSuffix(value, t.value)
+	case "*=":
+		return strings.Contains(value, t.value)
+	}
+	return false
+}
+
+func lookupAttr(node *html.Node, name string) (string, bool) {
+	for _, a := range node.Attr {
+		if a.Key == name {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+func attr(node *html.Node, name string) string {
+	value, _ := lookupAttr(node, name)
+	return value
+}
+
+func contains(values []string, want string) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
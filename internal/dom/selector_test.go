@@ -0,0 +1,47 @@
+package dom
+
+import (
+	"testing"
+)
+
+func TestCompileAttr(t *testing.T) {
+	tests := []struct {
+		selector string
+		expected attrTest
+	}{
+		{"[href]", attrTest{name: "href"}},
+		{"[data-automation-id=jobLink]", attrTest{name: "data-automation-id", op: "=", value: "jobLink"}},
+		{`[href*="a=b"]`, attrTest{name: "href", op: "*=", value: "a=b"}},
+		{`[data-x="~="]`, attrTest{name: "data-x", op: "=", value: "~="}},
+		{`[title ^= 'Senior ]']`, attrTest{name: "title", op: "^=", value: "Senior ]"}},
+		{`a[class~="job title"]`, attrTest{name: "class", op: "~=", value: "job title"}},
+	}
+	for _, test := range tests {
+		group, err := compile(test.selector)
+		if err != nil {
+			t.Errorf("compile(%q) failed: %v", test.selector, err)
+			continue
+		}
+		if attrs := group[0][0].attrs; len(attrs) != 1 || attrs[0] != test.expected {
+			t.Errorf("compile(%q) attrs = %+v, expected %+v", test.selector, attrs, test.expected)
+		}
+	}
+
+	for _, selector := range []string{"[]", "[href", `[href="a]`, "[=x]", "[href x]"} {
+		if _, err := compile(selector); err == nil {
+			t.Errorf("compile(%q): expected an error", selector)
+		}
+	}
+}
+
+func TestAttrValueMatches(t *testing.T) {
+	doc, err := Parse(`<a href="/jobs?a=b" data-x="~=">Apply</a>`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	for _, selector := range []string{`a[href*="a=b"]`, `[data-x="~="]`} {
+		if got := doc.First(selector).Text(); got != "Apply" {
+			t.Errorf("First(%q).Text() = %q, expected Apply", selector, got)
+		}
+	}
+}
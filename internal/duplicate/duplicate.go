@@ -0,0 +1,193 @@
+// Package duplicate finds saved applications that are likely the same job as
+// a newly parsed one, even when it was captured from a different board or on
+// a different day.
+package duplicate
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+
+	"track-my-job-apps/internal/models"
+)
+
+// TitleThreshold is the title similarity above which two applications to the
+// same company are reported as possible duplicates
+const TitleThreshold = 0.8
+
+// Candidate is a saved application that may be the same job
+type Candidate struct {
+	App models.JobApplication `json:"app"`
+	// Score is 1 for an exact board job ID or URL match, otherwise the title similarity
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// companySuffixes are legal-entity words dropped when comparing company names
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true,
+	"corp": true, "corporation": true, "co": true, "company": true, "gmbh": true,
+	"plc": true, "ag": true, "sa": true, "bv": true, "pty": true, "the": true,
+}
+
+// titleAbbreviations expands the abbreviations boards use inconsistently
+var titleAbbreviations = map[string]string{
+	"sr":   "senior",
+	"jr":   "junior",
+	"eng":  "engineer",
+	"engr": "engineer",
+	"swe":  "software engineer",
+	"sde":  "software engineer",
+	"mgr":  "manager",
+	"dev":  "developer",
+	"ii":   "2",
+	"iii":  "3",
+	"iv":   "4",
+}
+
+// Find returns the saved applications that look like the same job as app,
+// best match first
+func Find(app *models.JobApplication, saved []models.JobApplication) []Candidate {
+	company := NormalizeCompany(app.Company)
+
+	var candidates []Candidate
+	for _, existing := range saved {
+		if app.AppId != 0 && existing.AppId == app.AppId {
+			continue
+		}
+
+		candidate := Candidate{App: existing}
+		sameCompany := company != "" && company == NormalizeCompany(existing.Company)
+		// Job IDs are only unique within a board, so they count when the
+		// postings share a host or a company
+		if app.BoardJobId != "" && app.BoardJobId == existing.BoardJobId &&
+			(sameHost(app.CanonicalURL, existing.CanonicalURL) || sameCompany) {
+			candidate.Score = 1
+			candidate.Reasons = append(candidate.Reasons, "same board job ID")
+		}
+		if app.CanonicalURL != "" && app.CanonicalURL == existing.CanonicalURL {
+			candidate.Score = 1
+			candidate.Reasons = append(candidate.Reasons, "same posting URL")
+		}
+		if sameCompany {
+			if similarity := TitleSimilarity(app.Position, existing.Position); similarity >= TitleThreshold {
+				candidate.Score = max(candidate.Score, similarity)
+				candidate.Reasons = append(candidate.Reasons, "same company, similar title")
+			}
+		}
+
+		if len(candidate.Reasons) > 0 {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates
+}
+
+// sameHost reports whether two posting URLs are on the same host
+func sameHost(a string, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil || ua.Host == "" {
+		return false
+	}
+	return strings.EqualFold(ua.Hostname(), ub.Hostname())
+}
+
+// NormalizeCompany lowercases a company name and drops punctuation and
+// legal suffixes, so "Acme, Inc." and "ACME" compare equal
+func NormalizeCompany(name string) string {
+	var words []string
+	for _, word := range tokenize(name) {
+		if !companySuffixes[word] {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// TitleSimilarity is the Dice coefficient of the two titles' normalized words,
+// from 0 (nothing shared) to 1 (same words)
+func TitleSimilarity(a string, b string) float64 {
+	wordsA, wordsB := titleWords(a), titleWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	counts := map[string]int{}
+	for _, word := range wordsA {
+		counts[word]++
+	}
+	shared := 0
+	for _, word := range wordsB {
+		if counts[word] > 0 {
+			counts[word]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(wordsA)+len(wordsB))
+}
+
+// Merge fills the fields of existing that are empty with values from incoming
+// and appends incoming notes and tags, keeping the saved status and dates
+func Merge(existing *models.JobApplication, incoming *models.JobApplication) {
+	fill := func(dst *string, src string) {
+		if strings.TrimSpace(*dst) == "" {
+			*dst = src
+		}
+	}
+	fill(&existing.Location, incoming.Location)
+	fill(&existing.WorkplaceType, incoming.WorkplaceType)
+	fill(&existing.EmploymentType, incoming.EmploymentType)
+	fill(&existing.Department, incoming.Department)
+	fill(&existing.Description, incoming.Description)
+	fill(&existing.Website, incoming.Website)
+	fill(&existing.SourceURL, incoming.SourceURL)
+	fill(&existing.CanonicalURL, incoming.CanonicalURL)
+	fill(&existing.BoardJobId, incoming.BoardJobId)
+	fill(&existing.RequisitionId, incoming.RequisitionId)
+
+	if existing.SalaryRange == "" && incoming.SalaryRange != "" {
+		existing.SalaryRange = incoming.SalaryRange
+		existing.SalaryMin = incoming.SalaryMin
+		existing.SalaryMax = incoming.SalaryMax
+		existing.SalaryCurrency = incoming.SalaryCurrency
+		existing.SalaryPeriod = incoming.SalaryPeriod
+	}
+	if existing.DatePosted.IsZero() {
+		existing.DatePosted = incoming.DatePosted
+	}
+
+	existing.Tags = models.NormalizeTags(append(existing.Tags, incoming.Tags...))
+
+	if notes := strings.TrimSpace(incoming.Notes); notes != "" && !strings.Contains(existing.Notes, notes) {
+		if existing.Notes == "" {
+			existing.Notes = notes
+		} else {
+			existing.Notes += "\n" + notes
+		}
+	}
+}
+
+func titleWords(title string) []string {
+	var words []string
+	for _, word := range tokenize(title) {
+		if expanded, ok := titleAbbreviations[word]; ok {
+			words = append(words, strings.Fields(expanded)...)
+			continue
+		}
+		words = append(words, word)
+	}
+	return words
+}
+
+// tokenize lowercases s and splits it into words, dropping punctuation
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
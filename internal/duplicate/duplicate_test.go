@@ -0,0 +1,94 @@
+package duplicate
+
+import (
+	"strings"
+	"testing"
+
+	"track-my-job-apps/internal/models"
+)
+
+func TestNormalizeCompany(t *testing.T) {
+	tests := map[string]string{
+		"Acme, Inc.":              "acme",
+		"ACME":                    "acme",
+		"The Trade Desk":          "trade desk",
+		"Stripe Payments Co. LLC": "stripe payments",
+		"":                        "",
+	}
+	for name, expected := range tests {
+		if got := NormalizeCompany(name); got != expected {
+			t.Errorf("NormalizeCompany(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	if got := TitleSimilarity("Sr. Software Eng, Platform", "Senior Software Engineer - Platform"); got != 1 {
+		t.Errorf("Expected abbreviations to match exactly, got %v", got)
+	}
+	if got := TitleSimilarity("Senior Software Engineer, Platform", "Senior Software Engineer, Payments"); got >= TitleThreshold {
+		t.Errorf("Expected different teams to stay below the threshold, got %v", got)
+	}
+	if got := TitleSimilarity("Product Designer", "Data Engineer"); got != 0 {
+		t.Errorf("Expected unrelated titles to score 0, got %v", got)
+	}
+}
+
+func TestFind(t *testing.T) {
+	saved := []models.JobApplication{
+		{AppId: 1, Company: "Acme Inc", Position: "Backend Engineer"},
+		{AppId: 2, Company: "Globex", Position: "Staff Engineer", BoardJobId: "7078460", CanonicalURL: "https://jobs.lever.co/globex/7078460"},
+		{AppId: 3, Company: "Initech", Position: "QA Lead", CanonicalURL: "https://jobs.lever.co/initech/abc"},
+		{AppId: 4, Company: "Acme", Position: "Product Manager"},
+	}
+
+	app := &models.JobApplication{Company: "ACME, Inc.", Position: "Backend Engineer", BoardJobId: "7078460", CanonicalURL: "https://jobs.lever.co/initech/abc"}
+	candidates := Find(app, saved)
+	if len(candidates) != 3 {
+		t.Fatalf("Expected 3 candidates, got %d: %+v", len(candidates), candidates)
+	}
+	ids := map[uint]bool{}
+	for _, candidate := range candidates {
+		ids[candidate.App.AppId] = true
+		if candidate.Score != 1 || len(candidate.Reasons) == 0 {
+			t.Errorf("Expected score 1 with a reason for app %d, got %v %v", candidate.App.AppId, candidate.Score, candidate.Reasons)
+		}
+	}
+	if !ids[1] || !ids[2] || !ids[3] {
+		t.Errorf("Expected apps 1, 2 and 3 to be candidates, got %v", ids)
+	}
+
+	// The same job ID on another board is a different posting
+	other := models.JobApplication{AppId: 5, Company: "Hooli", Position: "Staff Engineer", BoardJobId: "7078460", CanonicalURL: "https://boards.greenhouse.io/hooli/jobs/7078460"}
+	if candidates := Find(app, []models.JobApplication{other}); len(candidates) != 0 {
+		t.Errorf("Expected a job ID from another board not to match, got %+v", candidates)
+	}
+
+	// An application is never its own duplicate
+	if candidates := Find(&saved[0], saved); len(candidates) != 0 {
+		t.Errorf("Expected no candidates for a saved app, got %+v", candidates)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	existing := &models.JobApplication{Company: "Acme", Position: "Backend Engineer", Location: "Denver", Notes: "Referred by Sam", Status: models.PHONE_SCREEN, Tags: models.Tags{"golang"}}
+	incoming := &models.JobApplication{Location: "Remote", SalaryRange: "$150K - $180K", SalaryMin: 150000, SalaryMax: 180000, SalaryCurrency: "USD", BoardJobId: "42", Notes: "Also posted on LinkedIn", Status: models.SUBMITTED, Tags: models.Tags{"Remote", "golang"}}
+
+	Merge(existing, incoming)
+
+	if existing.Location != "Denver" {
+		t.Errorf("Expected saved location to be kept, got '%s'", existing.Location)
+	}
+	if existing.SalaryMax != 180000 || existing.BoardJobId != "42" {
+		t.Errorf("Expected empty fields to be filled, got salary %v, board job ID '%s'", existing.SalaryMax, existing.BoardJobId)
+	}
+	if existing.Notes != "Referred by Sam\nAlso posted on LinkedIn" {
+		t.Errorf("Expected notes to be appended, got %q", existing.Notes)
+	}
+	if existing.Status != models.PHONE_SCREEN {
+		t.Errorf("Expected saved status to be kept, got %s", existing.Status)
+	}
+	if strings.Join(existing.Tags, ",") != "golang,remote" {
+		t.Errorf("Expected tags to be combined, got %v", existing.Tags)
+	}
+}
@@ -3,7 +3,10 @@ package models
 import (
 	"database/sql/driver"
 	"fmt"
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // DateOnly represents a date without time
@@ -65,32 +68,153 @@ func (d *DateOnly) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Tags are lowercase labels on an application, stored comma-separated
+type Tags []string
+
+// NormalizeTags trims and lowercases tags, dropping empty ones, commas and duplicates
+func NormalizeTags(tags []string) Tags {
+	normalized := Tags{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, ",", " ")))
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// Scan implements the Scanner interface for database reads
+func (t *Tags) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*t = Tags{}
+	case string:
+		*t = NormalizeTags(strings.Split(v, ","))
+	case []byte:
+		*t = NormalizeTags(strings.Split(string(v), ","))
+	default:
+		return fmt.Errorf("cannot scan %T into Tags", value)
+	}
+	return nil
+}
+
+// Value implements the driver Valuer interface for database writes
+func (t Tags) Value() (driver.Value, error) {
+	return strings.Join(NormalizeTags(t), ","), nil
+}
+
 // Status enum for job application status
 type Status string
 
 const (
+	SAVED             Status = "SAVED"
 	SUBMITTED         Status = "SUBMITTED"
 	REJECTED          Status = "REJECTED"
 	PHONE_SCREEN      Status = "PHONE_SCREEN"
+	TAKE_HOME         Status = "TAKE_HOME"
 	REMOTE_INTERVIEW  Status = "REMOTE_INTERVIEW"
 	ON_SITE_INTERVIEW Status = "ON_SITE_INTERVIEW"
+	OFFER             Status = "OFFER"
+	ACCEPTED          Status = "ACCEPTED"
+	DECLINED          Status = "DECLINED"
+	WITHDRAWN         Status = "WITHDRAWN"
+	GHOSTED           Status = "GHOSTED"
+)
+
+// PayPeriod is the unit a salary amount is paid per
+type PayPeriod string
+
+const (
+	HOURLY  PayPeriod = "HOURLY"
+	DAILY   PayPeriod = "DAILY"
+	WEEKLY  PayPeriod = "WEEKLY"
+	MONTHLY PayPeriod = "MONTHLY"
+	ANNUAL  PayPeriod = "ANNUAL"
 )
 
 // JobApplication represents a job application
 type JobApplication struct {
-	AppId         uint     `gorm:"primaryKey;autoIncrement" json:"appId"`
-	Company       string   `gorm:"type:varchar(255);not null;uniqueIndex:idx_company_position_date" json:"company"`
-	Position      string   `gorm:"type:varchar(255);not null;uniqueIndex:idx_company_position_date" json:"position"`
-	Location      string   `gorm:"type:varchar(255)" json:"location"`
-	SalaryRange   string   `gorm:"type:varchar(100)" json:"salaryRange"`
-	WorkplaceType string   `gorm:"type:varchar(50)" json:"workplaceType"`
-	Status        Status   `gorm:"type:varchar(50);default:SUBMITTED" json:"status"`
-	Notes         string   `gorm:"type:text" json:"notes"`
-	Website       string   `gorm:"type:varchar(500)" json:"website"`
+	AppId          uint      `gorm:"primaryKey;autoIncrement" json:"appId"`
+	Company        string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_company_position_date,where:deleted_at IS NULL" json:"company"`
+	Position       string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_company_position_date" json:"position"`
+	Location       string    `gorm:"type:varchar(255)" json:"location"`
+	SalaryRange    string    `gorm:"type:varchar(100)" json:"salaryRange"`
+	SalaryMin      float64   `gorm:"index" json:"salaryMin"`
+	SalaryMax      float64   `gorm:"index" json:"salaryMax"`
+	SalaryCurrency string    `gorm:"type:varchar(3)" json:"salaryCurrency"`
+	SalaryPeriod   PayPeriod `gorm:"type:varchar(20)" json:"salaryPeriod"`
+	// Annualized salary in the configured home currency, computed on read
+	AnnualizedSalary   float64 `gorm:"-" json:"annualizedSalary"`
+	AnnualizedCurrency string  `gorm:"-" json:"annualizedCurrency"`
+	WorkplaceType      string  `gorm:"type:varchar(50)" json:"workplaceType"`
+	EmploymentType     string  `gorm:"type:varchar(50)" json:"employmentType"`
+	Department         string  `gorm:"type:varchar(255)" json:"department"`
+	Status             Status  `gorm:"type:varchar(50);default:SUBMITTED" json:"status"`
+	Notes              string  `gorm:"type:text" json:"notes"`
+	// Description is the full job description as Markdown-ish text
+	Description string `gorm:"type:text" json:"description"`
+	Website     string `gorm:"type:varchar(500)" json:"website"`
+	// SourceURL is the page the posting was captured from; CanonicalURL drops
+	// tracking parameters and BoardJobId is the job board's own posting ID
+	SourceURL     string   `gorm:"type:varchar(1000)" json:"sourceUrl"`
+	CanonicalURL  string   `gorm:"type:varchar(1000);index" json:"canonicalUrl"`
+	BoardJobId    string   `gorm:"type:varchar(100);index" json:"boardJobId"`
 	DateApplied   DateOnly `gorm:"type:varchar(10);uniqueIndex:idx_company_position_date" json:"dateApplied"`
+	DatePosted    DateOnly `gorm:"type:varchar(10)" json:"datePosted"`
+	RequisitionId string   `gorm:"type:varchar(100)" json:"requisitionId"`
+	Tags          Tags     `gorm:"type:text" json:"tags"`
+	// DeletedAt is set when the application is moved to the trash. Trashed
+	// applications are left out of the company, position and date unique index.
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deletedAt"`
 }
 
 // TableName specifies the table name for GORM
 func (JobApplication) TableName() string {
 	return "apps"
 }
+
+// StatusEvent records one change of an application's status
+type StatusEvent struct {
+	Id         uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	AppId      uint      `gorm:"not null;index" json:"appId"`
+	FromStatus Status    `gorm:"type:varchar(50)" json:"fromStatus"`
+	ToStatus   Status    `gorm:"type:varchar(50);not null" json:"toStatus"`
+	ChangedAt  time.Time `gorm:"not null;index" json:"changedAt"`
+	Note       string    `gorm:"type:text" json:"note"`
+}
+
+// TableName specifies the table name for GORM
+func (StatusEvent) TableName() string {
+	return "status_events"
+}
+
+// OperationKind is the kind of change an Operation records
+type OperationKind string
+
+const (
+	CREATE  OperationKind = "CREATE"
+	UPDATE  OperationKind = "UPDATE"
+	DELETE  OperationKind = "DELETE"
+	RESTORE OperationKind = "RESTORE"
+)
+
+// Operation records one change made through the app so it can be undone and redone
+type Operation struct {
+	Id    uint          `gorm:"primaryKey;autoIncrement" json:"id"`
+	Kind  OperationKind `gorm:"type:varchar(20);not null" json:"kind"`
+	AppId uint          `gorm:"not null;index" json:"appId"`
+	// Before and After are JSON snapshots of the application around an UPDATE
+	Before string `gorm:"type:text" json:"-"`
+	After  string `gorm:"type:text" json:"-"`
+	// Summary describes the change, e.g. "Edited Engineer at Acme"
+	Summary   string    `gorm:"type:varchar(500)" json:"summary"`
+	Undone    bool      `gorm:"not null;default:false" json:"undone"`
+	CreatedAt time.Time `gorm:"not null" json:"createdAt"`
+}
+
+// TableName specifies the table name for GORM
+func (Operation) TableName() string {
+	return "operations"
+}
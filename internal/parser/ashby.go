@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"track-my-job-apps/internal/dom"
+	"track-my-job-apps/internal/models"
+)
+
+func init() {
+	Register(&board{
+		name:    "ashby",
+		hosts:   []string{"jobs.ashbyhq.com"},
+		markers: []string{"ashby-job-posting-heading", "ashby-job-posting-left-pane"},
+		parse:   ParseAshbyJob,
+		assess: func(content string, result *ParseResult) {
+			assessCompany(result, accountFromPath(ExtractSourceURL(content)))
+		},
+	})
+}
+
+var ashbyCurrencyPattern = regexp.MustCompile(`[$€£¥]|\b(?:USD|EUR|GBP|CAD|AUD)\b`)
+
+// ParseAshbyJob extracts job data from Ashby (jobs.ashbyhq.com) HTML content
+func ParseAshbyJob(lines []string, jobApp *models.JobApplication) (*models.JobApplication, error) {
+	// Join all lines into a single HTML string
+	htmlContent := strings.Join(lines, "\n")
+
+	doc, err := dom.Parse(htmlContent)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Extract job title from <h1 class="ashby-job-posting-heading">
+	jobApp.Position = doc.First("h1.ashby-job-posting-heading").Text()
+
+	// 2. The left pane lists each attribute under an <h2> heading
+	jobApp.Location = strings.Join(ashbySection(doc, "Location"), "; ")
+	jobApp.Department = strings.Join(ashbySection(doc, "Department"), "; ")
+	if employmentType := ashbySection(doc, "Employment Type"); len(employmentType) > 0 {
+		jobApp.EmploymentType = employmentType[0]
+	}
+	if locationType := ashbySection(doc, "Location Type"); len(locationType) > 0 {
+		jobApp.WorkplaceType = locationType[0]
+	}
+
+	// 3. Remote flag: postings without a location type still mark remote locations
+	if jobApp.WorkplaceType == "" && strings.Contains(strings.ToLower(jobApp.Location), "remote") {
+		jobApp.WorkplaceType = "Remote"
+	}
+
+	// 4. Compensation tiers, e.g. "Zone 1 (NYC, SF): $190K – $240K"
+	if tiers := ashbyCompensationTiers(ashbySection(doc, "Compensation")); len(tiers) > 0 {
+		jobApp.SalaryRange = strings.Join(tiers, "; ")
+		if matches := ExtractSalaries(jobApp.SalaryRange); len(matches) > 0 {
+			setSalaryFields(matches, jobApp)
+		}
+	}
+
+	// 5. Keep the full description from the right-hand _descriptionText pane
+	jobApp.Description = describe(doc.Find(`[class*="_descriptionText"]`))
+
+	// 6. Record the "mf-URL:" source and board job ID
+	setSource(htmlContent, jobApp)
+
+	// 7. Company name comes from the nav wordmark, or the account slug in the URL
+	if logo := doc.First(`img[class*="navLogo"]`); logo != nil {
+		jobApp.Company = strings.TrimSpace(trimSuffixFold(logo.Attr("alt"), " logo"))
+	}
+	if jobApp.Company == "" {
+		jobApp.Company = accountFromPath(ExtractSourceURL(htmlContent))
+	}
+
+	jobApp.Status = models.SUBMITTED
+	jobApp.DateApplied = models.DateOnly{Time: time.Now()}
+
+	return jobApp, nil
+}
+
+// ashbySection returns the text leaves of the section an <h2>heading</h2> opens
+func ashbySection(doc *dom.Node, heading string) []string {
+	for _, h2 := range doc.Find("h2") {
+		if h2.Text() != heading {
+			continue
+		}
+
+		var values []string
+		for _, leaf := range h2.ParentElement().Find("p, li, span") {
+			if leaf.First("*") != nil {
+				continue
+			}
+			if value := leaf.Text(); value != "" {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// ashbyCompensationTiers pairs each tier label with the range that follows it.
+// When the posting has no labelled tiers the summary ranges are returned instead.
+func ashbyCompensationTiers(values []string) []string {
+	var summary, tiers []string
+	label := ""
+	for _, value := range values {
+		if !ashbyCurrencyPattern.MatchString(value) {
+			label = value
+			continue
+		}
+		if label != "" {
+			tiers = append(tiers, label+": "+value)
+			label = ""
+		} else {
+			summary = append(summary, value)
+		}
+	}
+
+	if len(tiers) > 0 {
+		return tiers
+	}
+	return summary
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"track-my-job-apps/internal/models"
+)
+
+func TestParseAshbyJob(t *testing.T) {
+	// Read the test HTML data copied by the Chrome extension
+	testData, err := os.ReadFile("testdata/ashby.txt")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	lines := strings.Split(string(testData), "\n")
+	jobApp := &models.JobApplication{}
+
+	result, err := ParseAshbyJob(lines, jobApp)
+	if err != nil {
+		t.Fatalf("ParseAshbyJob failed: %v", err)
+	}
+
+	assertParsed(t, result, map[string]string{
+		"Company":        "Ramp",
+		"Position":       "Senior Backend Engineer, Payments",
+		"Location":       "New York, NY; San Francisco, CA",
+		"Department":     "Engineering & Data",
+		"EmploymentType": "Full time",
+		"WorkplaceType":  "Remote",
+		"SalaryRange":    "Zone 1 (NYC, SF): $190K – $240K; Zone 2 (Remote US): $171K – $216K",
+	})
+
+	if result.SalaryMin != 171000 || result.SalaryMax != 240000 || result.SalaryPeriod != models.ANNUAL {
+		t.Errorf("Expected annual salary 171000-240000 across tiers, got %v-%v %s", result.SalaryMin, result.SalaryMax, result.SalaryPeriod)
+	}
+
+	if !strings.Contains(result.Description, "### What you'll do\n\n- Own the card authorization path\n- Scale ledger services") {
+		t.Errorf("Expected description with headings and bullets, got: %s", result.Description)
+	}
+
+	if result.Status != models.SUBMITTED {
+		t.Errorf("Expected status to be SUBMITTED, got %s", result.Status)
+	}
+
+	p, err := Select("ashby", string(testData))
+	if err != nil || p.Name() != "ashby" {
+		t.Errorf("Expected ashby platform to be accepted, got %v, %v", p, err)
+	}
+	p, err = Detect(string(testData))
+	if err != nil || p.Name() != "ashby" {
+		t.Errorf("Expected ashby parser to be detected, got %v, %v", p, err)
+	}
+}
+
+func TestParseAshbyJobRemoteLocation(t *testing.T) {
+	testHTML := `
+	<h1 class="ashby-job-posting-heading">Product Designer</h1>
+	<div class="ashby-job-posting-left-pane">
+		<div><h2>Location</h2><p>Remote (Canada)</p></div>
+		<div><h2>Compensation</h2><ul><li><span>CA$120K – CA$150K</span></li></ul></div>
+	</div>
+	'mf-URL: https://jobs.ashbyhq.com/linear/0f9e8d7c-6b5a-4c3d-2e1f-0a9b8c7d6e5f
+	`
+
+	result, err := ParseAshbyJob(strings.Split(testHTML, "\n"), &models.JobApplication{})
+	if err != nil {
+		t.Fatalf("ParseAshbyJob failed: %v", err)
+	}
+
+	if result.Company != "linear" {
+		t.Errorf("Expected company 'linear', got '%s'", result.Company)
+	}
+	if result.WorkplaceType != "Remote" {
+		t.Errorf("Expected workplace type 'Remote', got '%s'", result.WorkplaceType)
+	}
+	if result.SalaryRange != "CA$120K – CA$150K" {
+		t.Errorf("Expected salary range 'CA$120K – CA$150K', got '%s'", result.SalaryRange)
+	}
+	if result.SalaryCurrency != "CAD" {
+		t.Errorf("Expected salary currency 'CAD', got '%s'", result.SalaryCurrency)
+	}
+}
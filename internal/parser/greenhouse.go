@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"track-my-job-apps/internal/dom"
+	"track-my-job-apps/internal/models"
+)
+
+func init() {
+	Register(&board{
+		name:    "greenhouse",
+		hosts:   []string{"greenhouse.io"},
+		markers: []string{"job__title", "greenhouse.io/"},
+		parse:   ParseGreenhouseJob,
+		assess: func(content string, result *ParseResult) {
+			assessCompany(result, greenhouseAccount(ExtractSourceURL(content)))
+		},
+	})
+}
+
+// ParseGreenhouseJob extracts job data from Greenhouse HTML content
+func ParseGreenhouseJob(lines []string, jobApp *models.JobApplication) (*models.JobApplication, error) {
+	// Join all lines into a single HTML string
+	htmlContent := strings.Join(lines, "\n")
+
+	doc, err := dom.Parse(htmlContent)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Extract job title from <div class="job__title"><h1>, leaving out
+	// badges like "New" that some boards render inside it
+	jobApp.Position = doc.First(".job__title h1").TextExcept(".badge")
+
+	// 2. Extract location from <div class="job__location"><div>
+	jobApp.Location = doc.First(".job__location div").Text()
+
+	// 3. Keep the full description from <div class="job__description">
+	jobApp.Description = doc.First(".job__description").Markdown()
+
+	// 4. Find salary patterns like $100,000 - $150,000
+	applySalary(doc.Text(), jobApp)
+
+	// 5. Record the "mf-URL:" source and board job ID
+	setSource(htmlContent, jobApp)
+
+	// 6. Extract company name from the logo, e.g. <img alt="Cloudflare Logo">
+	if logo := doc.First(`img[alt$="Logo"]`); logo != nil {
+		jobApp.Company = strings.TrimSpace(strings.TrimSuffix(logo.Attr("alt"), "Logo"))
+	}
+	if jobApp.Company == "" {
+		jobApp.Company = greenhouseAccount(ExtractSourceURL(htmlContent))
+	}
+
+	jobApp.Status = models.SUBMITTED
+	jobApp.DateApplied = models.DateOnly{Time: time.Now()}
+
+	return jobApp, nil
+}
+
+// greenhouseAccount returns the board token from job-boards.greenhouse.io/<company>/jobs/<id>
+// or boards.greenhouse.io/embed/job_app?for=<company> URLs
+func greenhouseAccount(sourceURL string) string {
+	account := accountFromPath(sourceURL)
+	if account != "embed" {
+		return account
+	}
+	if u, err := url.Parse(sourceURL); err == nil {
+		return u.Query().Get("for")
+	}
+	return ""
+}
@@ -0,0 +1,294 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"track-my-job-apps/internal/dom"
+	"track-my-job-apps/internal/models"
+)
+
+func init() {
+	RegisterFallback(jsonLDParser{})
+}
+
+// jsonLDParser reads the schema.org JobPosting most career sites embed as JSON-LD
+type jsonLDParser struct{}
+
+func (jsonLDParser) Name() string {
+	return "jsonld"
+}
+
+func (jsonLDParser) MatchesURL(sourceURL string) bool {
+	return false
+}
+
+func (jsonLDParser) MatchesContent(content string) bool {
+	return findJobPosting(content) != nil
+}
+
+func (jsonLDParser) Parse(lines []string, jobApp *models.JobApplication) (*models.JobApplication, error) {
+	return ParseJSONLDJob(lines, jobApp)
+}
+
+var jsonLDScriptPattern = regexp.MustCompile(`(?is)<script[^>]*type=["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+
+var employmentTypeLabels = map[string]string{
+	"FULL_TIME":  "Full-time",
+	"PART_TIME":  "Part-time",
+	"CONTRACTOR": "Contract",
+	"TEMPORARY":  "Temporary",
+	"INTERN":     "Internship",
+	"VOLUNTEER":  "Volunteer",
+	"PER_DIEM":   "Per diem",
+}
+
+// ParseJSONLDJob extracts job data from a schema.org JobPosting embedded as
+// <script type="application/ld+json"> in any career site
+func ParseJSONLDJob(lines []string, jobApp *models.JobApplication) (*models.JobApplication, error) {
+	// Join all lines into a single HTML string
+	htmlContent := strings.Join(lines, "\n")
+
+	posting := findJobPosting(htmlContent)
+	if posting == nil {
+		return nil, fmt.Errorf("%w: no schema.org JobPosting found", ErrUnsupportedSource)
+	}
+
+	// 1. Title and hiring organization
+	jobApp.Position = CleanHTMLTags(jsonLDString(posting["title"]))
+	if org := jsonLDString(posting["hiringOrganization"]); org != "" {
+		jobApp.Company = CleanHTMLTags(org)
+	}
+
+	// 2. Locations, joined when the posting lists several
+	var locations []string
+	for _, place := range jsonLDList(posting["jobLocation"]) {
+		if location := jsonLDPlace(place); location != "" {
+			locations = append(locations, location)
+		}
+	}
+	jobApp.Location = strings.Join(locations, "; ")
+
+	// 3. TELECOMMUTE marks a remote posting
+	if strings.EqualFold(jsonLDString(posting["jobLocationType"]), "TELECOMMUTE") {
+		jobApp.WorkplaceType = "Remote"
+		if jobApp.Location == "" {
+			jobApp.Location = "Remote"
+		}
+	}
+
+	// 4. Employment type may be a string or a list
+	var employmentTypes []string
+	for _, value := range jsonLDList(posting["employmentType"]) {
+		employmentType := jsonLDString(value)
+		if label, ok := employmentTypeLabels[strings.ToUpper(employmentType)]; ok {
+			employmentType = label
+		}
+		if employmentType != "" {
+			employmentTypes = append(employmentTypes, employmentType)
+		}
+	}
+	jobApp.EmploymentType = strings.Join(employmentTypes, ", ")
+
+	// 5. Salary from baseSalary's MonetaryAmount
+	if salary, ok := posting["baseSalary"].(map[string]interface{}); ok {
+		jsonLDSalary(salary, jobApp)
+	}
+
+	// 6. Posted date and requisition identifier
+	if datePosted := jsonLDString(posting["datePosted"]); len(datePosted) >= 10 {
+		if posted, err := time.Parse("2006-01-02", datePosted[:10]); err == nil {
+			jobApp.DatePosted = models.DateOnly{Time: posted}
+		}
+	}
+	if identifier, ok := posting["identifier"].(map[string]interface{}); ok {
+		jobApp.RequisitionId = jsonLDString(identifier["value"])
+		if jobApp.Company == "" {
+			jobApp.Company = jsonLDString(identifier["name"])
+		}
+	} else {
+		jobApp.RequisitionId = jsonLDString(posting["identifier"])
+	}
+
+	// 7. Keep the full description
+	jobApp.Description = jsonLDDescription(jsonLDString(posting["description"]))
+
+	// 8. Record the "mf-URL:" source and board job ID
+	setSource(htmlContent, jobApp)
+
+	jobApp.Status = models.SUBMITTED
+	jobApp.DateApplied = models.DateOnly{Time: time.Now()}
+
+	return jobApp, nil
+}
+
+// findJobPosting decodes every JSON-LD script and returns the first JobPosting,
+// looking through top-level arrays and @graph containers
+func findJobPosting(htmlContent string) map[string]interface{} {
+	for _, match := range jsonLDScriptPattern.FindAllStringSubmatch(htmlContent, -1) {
+		var data interface{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(match[1])), &data); err != nil {
+			continue
+		}
+		if posting := findJobPostingNode(data); posting != nil {
+			return posting
+		}
+	}
+	return nil
+}
+
+func findJobPostingNode(data interface{}) map[string]interface{} {
+	switch node := data.(type) {
+	case []interface{}:
+		for _, item := range node {
+			if posting := findJobPostingNode(item); posting != nil {
+				return posting
+			}
+		}
+	case map[string]interface{}:
+		for _, t := range jsonLDList(node["@type"]) {
+			if jsonLDString(t) == "JobPosting" {
+				return node
+			}
+		}
+		if graph, ok := node["@graph"]; ok {
+			return findJobPostingNode(graph)
+		}
+	}
+	return nil
+}
+
+// jsonLDDescription renders the posting description, which sites embed as
+// HTML and sometimes entity-escape a second time
+func jsonLDDescription(description string) string {
+	if !strings.Contains(description, "<") && strings.Contains(description, "&lt;") {
+		description = html.UnescapeString(description)
+	}
+	doc, err := dom.Parse(description)
+	if err != nil {
+		return ""
+	}
+	return doc.Markdown()
+}
+
+// jsonLDString returns a string value, or the name of a nested object
+func jsonLDString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}:
+		return jsonLDString(v["name"])
+	}
+	return ""
+}
+
+// jsonLDList wraps single values so properties that may be a value or a list can be ranged over
+func jsonLDList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	}
+	return []interface{}{value}
+}
+
+// jsonLDPlace formats a Place's PostalAddress as "Locality, Region, Country"
+func jsonLDPlace(place interface{}) string {
+	placeMap, ok := place.(map[string]interface{})
+	if !ok {
+		return jsonLDString(place)
+	}
+
+	address, ok := placeMap["address"].(map[string]interface{})
+	if !ok {
+		if text := jsonLDString(placeMap["address"]); text != "" {
+			return text
+		}
+		return jsonLDString(placeMap["name"])
+	}
+
+	var parts []string
+	for _, key := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+		if part := jsonLDString(address[key]); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// jsonLDSalary stores a MonetaryAmount, formatting the raw text as "$120,000 - $150,000 per year"
+func jsonLDSalary(salary map[string]interface{}, jobApp *models.JobApplication) {
+	currency := strings.ToUpper(jsonLDString(salary["currency"]))
+	value, ok := salary["value"].(map[string]interface{})
+	if !ok {
+		value = map[string]interface{}{"value": salary["value"]}
+	}
+
+	minValue, hasMin := value["minValue"].(float64)
+	maxValue, hasMax := value["maxValue"].(float64)
+	if exact, ok := value["value"].(float64); ok && !hasMin && !hasMax {
+		minValue, maxValue, hasMin, hasMax = exact, exact, true, true
+	}
+	if !hasMin && !hasMax {
+		return
+	}
+	if !hasMin {
+		minValue = maxValue
+	}
+	if !hasMax {
+		maxValue = minValue
+	}
+
+	amounts := []string{formatAmount(currency, minValue)}
+	if maxValue != minValue {
+		amounts = append(amounts, formatAmount(currency, maxValue))
+	}
+	jobApp.SalaryRange = strings.Join(amounts, " - ")
+
+	unit := jsonLDString(value["unitText"])
+	if unit != "" {
+		jobApp.SalaryRange += " per " + strings.ToLower(unit)
+	}
+
+	if currency == "" {
+		currency = "USD"
+	}
+	jobApp.SalaryMin = minValue
+	jobApp.SalaryMax = maxValue
+	jobApp.SalaryCurrency = currency
+	jobApp.SalaryPeriod = parsePeriod(unit)
+	if jobApp.SalaryPeriod == "" {
+		jobApp.SalaryPeriod = inferPeriod(maxValue)
+	}
+}
+
+// formatAmount renders an amount with thousands separators and the currency
+// symbol or code, keeping cents when there are any, e.g. "$42.50"
+func formatAmount(currency string, amount float64) string {
+	digits, cents, _ := strings.Cut(strconv.FormatFloat(amount, 'f', 2, 64), ".")
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	if cents != "00" {
+		grouped.WriteString("." + cents)
+	}
+
+	switch strings.ToUpper(currency) {
+	case "", "USD":
+		return "$" + grouped.String()
+	default:
+		return strings.ToUpper(currency) + " " + grouped.String()
+	}
+}
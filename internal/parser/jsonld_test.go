@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"track-my-job-apps/internal/models"
+)
+
+func TestParseJSONLDJob(t *testing.T) {
+	// Read the test HTML data copied by the Chrome extension
+	testData, err := os.ReadFile("testdata/jsonld.txt")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	lines := strings.Split(string(testData), "\n")
+	jobApp := &models.JobApplication{}
+
+	result, err := ParseJSONLDJob(lines, jobApp)
+	if err != nil {
+		t.Fatalf("ParseJSONLDJob failed: %v", err)
+	}
+
+	assertParsed(t, result, map[string]string{
+		"Company":        "Shopwise",
+		"Position":       "Staff Platform Engineer",
+		"Location":       "Toronto, ON, CA; Austin, TX, US",
+		"EmploymentType": "Full-time, Contract",
+		"SalaryRange":    "$185,000 - $230,000 per year",
+		"RequisitionId":  "ENG-2291",
+		"DatePosted":     "2024-05-02",
+	})
+
+	if result.Description != "Shopwise powers checkout for independent retailers.\n\n## What you'll do\n\n- Run our Kubernetes fleet\n- Own deploy tooling" {
+		t.Errorf("Expected escaped HTML description to be rendered, got: %q", result.Description)
+	}
+
+	if result.SalaryMin != 185000 || result.SalaryMax != 230000 || result.SalaryCurrency != "USD" || result.SalaryPeriod != models.ANNUAL {
+		t.Errorf("Expected annual salary 185000-230000 USD, got %v-%v %s %s", result.SalaryMin, result.SalaryMax, result.SalaryCurrency, result.SalaryPeriod)
+	}
+
+	if result.Status != models.SUBMITTED {
+		t.Errorf("Expected status to be SUBMITTED, got %s", result.Status)
+	}
+}
+
+func TestDetectFallsBackToJSONLD(t *testing.T) {
+	testData, err := os.ReadFile("testdata/jsonld.txt")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	p, err := Detect(string(testData))
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if p.Name() != "jsonld" {
+		t.Errorf("Expected jsonld parser for unknown board, got '%s'", p.Name())
+	}
+
+	// A board-specific parser still wins when the page also embeds JSON-LD
+	greenhouse := `<div class="job__title"><h1>Engineer</h1></div>` + "\n" + string(testData)
+	greenhouse = strings.Replace(greenhouse, "https://careers.shopwise.example/jobs/eng-2291-staff-platform-engineer", "https://job-boards.greenhouse.io/shopwise/jobs/2291", 1)
+	if p, err := Detect(greenhouse); err != nil || p.Name() != "greenhouse" {
+		t.Errorf("Expected greenhouse parser to win over JSON-LD, got %v, %v", p, err)
+	}
+}
+
+func TestParseJSONLDJobRemote(t *testing.T) {
+	testHTML := `<script type="application/ld+json">{"@context":"https://schema.org/","@type":"JobPosting","title":"Support Engineer","hiringOrganization":"Helpdesk Co","jobLocationType":"TELECOMMUTE","employmentType":"PART_TIME","baseSalary":{"@type":"MonetaryAmount","currency":"EUR","value":{"@type":"QuantitativeValue","value":28,"unitText":"HOUR"}}}</script>`
+
+	result, err := ParseJSONLDJob(strings.Split(testHTML, "\n"), &models.JobApplication{})
+	if err != nil {
+		t.Fatalf("ParseJSONLDJob failed: %v", err)
+	}
+
+	if result.Company != "Helpdesk Co" {
+		t.Errorf("Expected company 'Helpdesk Co', got '%s'", result.Company)
+	}
+	if result.WorkplaceType != "Remote" || result.Location != "Remote" {
+		t.Errorf("Expected remote workplace and location, got '%s' / '%s'", result.WorkplaceType, result.Location)
+	}
+	if result.EmploymentType != "Part-time" {
+		t.Errorf("Expected employment type 'Part-time', got '%s'", result.EmploymentType)
+	}
+	if result.SalaryRange != "EUR 28 per hour" {
+		t.Errorf("Expected salary 'EUR 28 per hour', got '%s'", result.SalaryRange)
+	}
+	if result.SalaryMin != 28 || result.SalaryPeriod != models.HOURLY {
+		t.Errorf("Expected hourly salary of 28, got %v %s", result.SalaryMin, result.SalaryPeriod)
+	}
+}
+
+func TestParseJSONLDJobHourlyCents(t *testing.T) {
+	testHTML := `<script type="application/ld+json">{"@type":"JobPosting","title":"Barista","hiringOrganization":"Bean Co","baseSalary":{"@type":"MonetaryAmount","currency":"USD","value":{"@type":"QuantitativeValue","minValue":18.75,"maxValue":42.5,"unitText":"HOUR"}}}</script>`
+
+	result, err := ParseJSONLDJob([]string{testHTML}, &models.JobApplication{})
+	if err != nil {
+		t.Fatalf("ParseJSONLDJob failed: %v", err)
+	}
+	if result.SalaryRange != "$18.75 - $42.50 per hour" {
+		t.Errorf("Expected salary '$18.75 - $42.50 per hour', got '%s'", result.SalaryRange)
+	}
+
+	// Editing the text parses it again to the same amounts
+	ReparseSalary(result)
+	if result.SalaryMin != 18.75 || result.SalaryMax != 42.5 || result.SalaryPeriod != models.HOURLY {
+		t.Errorf("Expected hourly 18.75-42.5 after reparsing, got %v-%v %s", result.SalaryMin, result.SalaryMax, result.SalaryPeriod)
+	}
+}
+
+func TestParseJSONLDJobNumericIdentifier(t *testing.T) {
+	for _, identifier := range []string{`1234567`, `{"@type":"PropertyValue","name":"Acme","value":1234567}`} {
+		testHTML := `<script type="application/ld+json">{"@type":"JobPosting","title":"Analyst","hiringOrganization":"Acme","identifier":` + identifier + `}</script>`
+
+		result, err := ParseJSONLDJob([]string{testHTML}, &models.JobApplication{})
+		if err != nil {
+			t.Fatalf("ParseJSONLDJob failed: %v", err)
+		}
+		if result.RequisitionId != "1234567" {
+			t.Errorf("Expected requisition ID '1234567' from %s, got '%s'", identifier, result.RequisitionId)
+		}
+	}
+}
+
+func TestParseJSONLDJobWithoutPosting(t *testing.T) {
+	_, err := ParseJSONLDJob([]string{`<script type="application/ld+json">{"@type":"Organization"}</script>`}, &models.JobApplication{})
+	if !errors.Is(err, ErrUnsupportedSource) {
+		t.Errorf("Expected ErrUnsupportedSource, got %v", err)
+	}
+}
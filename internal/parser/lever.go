@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"strings"
+	"time"
+
+	"track-my-job-apps/internal/dom"
+	"track-my-job-apps/internal/models"
+)
+
+func init() {
+	Register(&board{
+		name:    "lever",
+		hosts:   []string{"jobs.lever.co", "jobs.eu.lever.co"},
+		markers: []string{"posting-headline", "lever-client-logos"},
+		parse:   ParseLeverJob,
+		assess: func(content string, result *ParseResult) {
+			assessCompany(result, accountFromPath(ExtractSourceURL(content)))
+		},
+	})
+}
+
+// ParseLeverJob extracts job data from Lever (jobs.lever.co) HTML content
+func ParseLeverJob(lines []string, jobApp *models.JobApplication) (*models.JobApplication, error) {
+	// Join all lines into a single HTML string
+	htmlContent := strings.Join(lines, "\n")
+
+	doc, err := dom.Parse(htmlContent)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Extract job title from <div class="posting-headline"><h2>
+	jobApp.Position = doc.First(".posting-headline h2").Text()
+
+	// 2. Extract the posting categories (location, team, commitment, workplace type)
+	jobApp.Location = leverCategory(doc, "location")
+	jobApp.Department = leverCategory(doc, "department")
+	jobApp.EmploymentType = leverCategory(doc, "commitment")
+	jobApp.WorkplaceType = leverCategory(doc, "workplaceTypes")
+
+	// 3. Prefer the dedicated salary section, fall back to the whole page
+	if !applySalary(doc.First(`[data-qa="salary-range"]`).Text(), jobApp) {
+		applySalary(doc.Text(), jobApp)
+	}
+
+	// 4. Keep the description, requirement lists and closing text below the header
+	jobApp.Description = leverDescription(doc)
+
+	// 5. Record the "mf-URL:" source and board job ID
+	setSource(htmlContent, jobApp)
+
+	// 6. Company name comes from the header logo, or the account slug in the URL
+	if logo := doc.First(".main-header-logo img"); logo != nil {
+		jobApp.Company = strings.TrimSpace(trimSuffixFold(logo.Attr("alt"), " logo"))
+	}
+	if jobApp.Company == "" {
+		jobApp.Company = accountFromPath(ExtractSourceURL(htmlContent))
+	}
+
+	jobApp.Status = models.SUBMITTED
+	jobApp.DateApplied = models.DateOnly{Time: time.Now()}
+
+	return jobApp, nil
+}
+
+// leverCategory returns the text of the posting-category div carrying the given class.
+// Lever renders categories with trailing " /" separators, which are dropped.
+func leverCategory(doc *dom.Node, class string) string {
+	return strings.TrimSpace(strings.TrimSuffix(doc.First(".posting-category."+class).Text(), "/"))
+}
+
+// leverDescription joins the posting sections, skipping the header and apply button
+func leverDescription(doc *dom.Node) string {
+	var sections []*dom.Node
+	for _, section := range doc.Find(".posting-page .section.page-centered") {
+		if strings.Contains(section.Attr("class"), "posting-header") || section.Attr("data-qa") == "btn-apply-bottom" {
+			continue
+		}
+		sections = append(sections, section)
+	}
+	return describe(sections)
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"track-my-job-apps/internal/models"
+)
+
+func TestParseLeverJob(t *testing.T) {
+	// Read the test HTML data copied by the Chrome extension
+	testData, err := os.ReadFile("testdata/lever.txt")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	// Convert to lines as the function expects
+	lines := strings.Split(string(testData), "\n")
+	jobApp := &models.JobApplication{}
+
+	result, err := ParseLeverJob(lines, jobApp)
+	if err != nil {
+		t.Fatalf("ParseLeverJob failed: %v", err)
+	}
+
+	assertParsed(t, result, map[string]string{
+		"Company":        "Plaid",
+		"Position":       "Software Engineer - Data Platform",
+		"Location":       "San Francisco",
+		"Department":     "Engineering – Data & Infrastructure",
+		"EmploymentType": "Full-Time",
+		"WorkplaceType":  "Hybrid",
+		"SalaryRange":    "$163,200 - $223,200 a year",
+	})
+
+	if result.SalaryMin != 163200 || result.SalaryMax != 223200 || result.SalaryPeriod != models.ANNUAL {
+		t.Errorf("Expected annual salary 163200-223200, got %v-%v %s", result.SalaryMin, result.SalaryMax, result.SalaryPeriod)
+	}
+
+	if !strings.HasPrefix(result.Description, "We believe") || !strings.Contains(result.Description, "### Responsibilities\n\n- Design and operate batch and streaming pipelines") {
+		t.Errorf("Expected description with the responsibilities list, got: %s", result.Description)
+	}
+	if strings.Contains(result.Description, "Apply for this job") {
+		t.Errorf("Expected apply buttons to be left out of the description, got: %s", result.Description)
+	}
+
+	if result.CanonicalURL != "https://jobs.lever.co/plaid/8d3f1c62-4b2e-4f7a-9c55-2a1f3e7b9d10" {
+		t.Errorf("Expected lever-source to be stripped from the canonical URL, got: %s", result.CanonicalURL)
+	}
+	if result.BoardJobId != "8d3f1c62-4b2e-4f7a-9c55-2a1f3e7b9d10" {
+		t.Errorf("Expected posting UUID as board job ID, got '%s'", result.BoardJobId)
+	}
+	if result.Status != models.SUBMITTED {
+		t.Errorf("Expected status to be SUBMITTED, got %s", result.Status)
+	}
+	today := time.Now().Format("2006-01-02")
+	if appliedDate := result.DateApplied.Time.Format("2006-01-02"); appliedDate != today {
+		t.Errorf("Expected date applied to be today (%s), got %s", today, appliedDate)
+	}
+
+	p, err := Detect(string(testData))
+	if err != nil || p.Name() != "lever" {
+		t.Errorf("Expected lever parser to be detected, got %v, %v", p, err)
+	}
+}
+
+func TestParseLeverJobCompanyFromURL(t *testing.T) {
+	testHTML := `
+	<div class="posting-headline">
+		<h2>Account Executive</h2>
+		<div class="posting-categories">
+			<div class="sort-by-time posting-category medium-category-label location">Remote - US</div>
+		</div>
+	</div>
+	<p>The pay range for this role is $90,000 to $110,000.</p>
+	'mf-URL: https://jobs.lever.co/acme-corp/11111111-2222-3333-4444-555555555555
+	`
+
+	result, err := ParseLeverJob(strings.Split(testHTML, "\n"), &models.JobApplication{})
+	if err != nil {
+		t.Fatalf("ParseLeverJob failed: %v", err)
+	}
+
+	if result.Company != "acme-corp" {
+		t.Errorf("Expected company 'acme-corp', got '%s'", result.Company)
+	}
+	if result.Position != "Account Executive" {
+		t.Errorf("Expected position 'Account Executive', got '%s'", result.Position)
+	}
+	if result.Location != "Remote - US" {
+		t.Errorf("Expected location 'Remote - US', got '%s'", result.Location)
+	}
+	if result.SalaryMin != 90000 || result.SalaryMax != 110000 || result.SalaryCurrency != "USD" {
+		t.Errorf("Expected salary 90000-110000 USD, got %v-%v %s", result.SalaryMin, result.SalaryMax, result.SalaryCurrency)
+	}
+}
@@ -0,0 +1,309 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"track-my-job-apps/internal/dom"
+	"track-my-job-apps/internal/models"
+)
+
+func init() {
+	Register(&board{
+		name:    "linkedin",
+		hosts:   []string{"linkedin.com/jobs", "linkedin.com/comm/jobs"},
+		markers: []string{"Matches your job preferences", "jobs-unified-top-card", "Easy Apply"},
+		parse:   ParseLinkedInJob,
+		assess: func(content string, result *ParseResult) {
+			if linkedInSavePattern.MatchString(content) || strings.Contains(content, "top-card__job-title") || strings.Contains(content, "topcard__title") {
+				return
+			}
+			result.Flag("company", ConfidenceMedium, "")
+			result.Flag("position", ConfidenceMedium, "")
+			result.Warnings = append(result.Warnings, "company and position were read from the lines above the location")
+		},
+	})
+}
+
+var (
+	// linkedInSavePattern matches the "Save <position> at <company>" button label
+	linkedInSavePattern = regexp.MustCompile(`(?m)^\s*Save (.+) at (.+?)\s*$`)
+	linkedInAgoPattern  = regexp.MustCompile(`(?i)\b(\d+)\s+(minute|hour|day|week|month|year)s?\s+ago\b`)
+	linkedInHTMLPattern = regexp.MustCompile(`(?i)<(?:html|body|div|main|section|h1)\b`)
+	linkedInWorkplace   = map[string]string{"remote": "Remote", "hybrid": "Hybrid", "on-site": "On-site", "onsite": "On-site"}
+	linkedInEmployment  = map[string]string{
+		"full-time": "Full-time", "part-time": "Part-time", "contract": "Contract", "temporary": "Temporary",
+		"internship": "Internship", "volunteer": "Volunteer", "other": "Other",
+	}
+)
+
+// linkedInNoise are button labels and badges that shift the layout but carry no job data
+var linkedInNoise = []string{
+	"share", "show more options", "save", "apply", "easy apply", "promoted", "promoted by hirer",
+	"actively reviewing applicants", "actively recruiting", "responses managed off linkedin",
+	"be an early applicant", "no longer accepting applications", "verified", "your profile matches",
+	"see how you compare", "am i a good fit", "tailor my resume", "help me stand out", "how you match",
+	"show all", "try premium", "matches your job preferences", "reposted",
+}
+
+// linkedInNoisePrefixes are labels that end in a variable tail, like
+// "Save <position> at <company>" or "Posted on <date>"
+var linkedInNoisePrefixes = []string{"posted ", "reposted ", "save ", "use ai to assess", "your profile matches "}
+
+// linkedInApplicantsPattern matches applicant counts such as "Over 100 applicants"
+var linkedInApplicantsPattern = regexp.MustCompile(`^(?:over )?[\d,]+ (?:applicants?|people clicked apply)$`)
+
+// linkedInDescriptionEnd are the headings LinkedIn renders below the job description
+var linkedInDescriptionEnd = []string{
+	"about the company", "set alert for similar jobs", "show more", "see more", "… more",
+	"meet the hiring team", "people you can reach out to", "looking for talent?",
+}
+
+// ParseLinkedInJob extracts job data from a LinkedIn job view. It accepts both
+// the copied page text and the full HTML sent by the Chrome extension, reading
+// fields from LinkedIn's labels rather than fixed line positions.
+func ParseLinkedInJob(lines []string, jobApp *models.JobApplication) (*models.JobApplication, error) {
+	content := strings.Join(lines, "\n")
+
+	// 1. HTML is rendered to text lines so both inputs share the label rules
+	var doc *dom.Node
+	if linkedInHTMLPattern.MatchString(content) {
+		parsed, err := dom.Parse(strings.TrimPrefix(strings.TrimSpace(content), "HTML:"))
+		if err != nil {
+			return nil, err
+		}
+		doc = parsed
+		lines = strings.Split(doc.Markdown(), "\n")
+	}
+
+	// 2. Split the header from the "About the job" description
+	header, description := splitLinkedInDescription(lines)
+
+	// 3. The metadata line reads "Location · Reposted 2 weeks ago · Over 100 applicants";
+	// the position and company are the lines above it
+	meta := -1
+	var above []string
+	for i, line := range header {
+		if linkedInIsNoise(line) {
+			continue
+		}
+		if strings.Contains(line, "·") {
+			meta = i
+			break
+		}
+		above = append(above, line)
+	}
+	if meta == -1 {
+		above = nil
+		for _, line := range header {
+			if !linkedInIsNoise(line) && !linkedInIsPill(line) && len(ExtractSalaries(line)) == 0 {
+				above = append(above, line)
+			}
+		}
+		if len(above) > 2 {
+			above = above[:2]
+		}
+	}
+	if n := len(above); n > 0 {
+		jobApp.Position = above[n-1]
+		if n > 1 {
+			jobApp.Company = strings.TrimSpace(trimSuffixFold(above[n-2], " logo"))
+		}
+	}
+	if match := linkedInSavePattern.FindStringSubmatch(strings.Join(header, "\n")); len(match) > 2 {
+		jobApp.Position = strings.TrimSpace(match[1])
+		jobApp.Company = strings.TrimSpace(match[2])
+	}
+
+	// 4. Location, workplace type and posted date from the metadata parts
+	if meta != -1 {
+		parts := strings.Split(header[meta], "·")
+		location := strings.TrimSpace(parts[0])
+		for value, label := range linkedInWorkplace {
+			suffix := " (" + value + ")"
+			if len(location) > len(suffix) && strings.EqualFold(location[len(location)-len(suffix):], suffix) {
+				location = strings.TrimSpace(location[:len(location)-len(suffix)])
+				jobApp.WorkplaceType = label
+			}
+		}
+		jobApp.Location = location
+		for _, part := range parts[1:] {
+			if label, ok := linkedInWorkplace[strings.ToLower(strings.TrimSpace(part))]; ok {
+				jobApp.WorkplaceType = label
+			}
+		}
+	}
+	for _, line := range header {
+		if posted, ok := parseLinkedInPostedAgo(line, time.Now()); ok {
+			jobApp.DatePosted = models.DateOnly{Time: posted}
+			break
+		}
+	}
+
+	// 5. Workplace and job type pills, including the "Matches your job preferences" hints
+	for _, line := range header {
+		for _, pill := range linkedInPills(line) {
+			if label, ok := linkedInWorkplace[pill]; ok {
+				jobApp.WorkplaceType = label
+			}
+			if label, ok := linkedInEmployment[pill]; ok && jobApp.EmploymentType == "" {
+				jobApp.EmploymentType = label
+			}
+		}
+	}
+
+	// 6. Salary from the header, falling back to the description
+	if !applySalary(strings.Join(header, "\n"), jobApp) {
+		applySalary(strings.Join(description, "\n"), jobApp)
+	}
+
+	// 7. Description below "About the job", or the job details element when HTML was sent
+	jobApp.Description = strings.TrimSpace(strings.Join(description, "\n"))
+	if jobApp.Description == "" && doc != nil {
+		if details := doc.First("#job-details, .jobs-description__content, .description__text").Markdown(); details != "" {
+			jobApp.Description = details
+		}
+	}
+
+	// 8. Top card selectors win over the text rules when HTML was sent
+	if doc != nil {
+		if title := doc.First(".job-details-jobs-unified-top-card__job-title, .jobs-unified-top-card__job-title, .topcard__title").Text(); title != "" {
+			jobApp.Position = title
+		}
+		if company := doc.First(".job-details-jobs-unified-top-card__company-name, .jobs-unified-top-card__company-name, .topcard__org-name-link").Text(); company != "" {
+			jobApp.Company = company
+		}
+	}
+
+	// 9. Record the "mf-URL:" source and board job ID
+	setSource(content, jobApp)
+
+	jobApp.Status = models.SUBMITTED
+	jobApp.DateApplied = models.DateOnly{Time: time.Now()}
+
+	return jobApp, nil
+}
+
+// splitLinkedInDescription trims the lines and splits them at "About the job",
+// ending the description at the company and hiring team sections
+func splitLinkedInDescription(lines []string) ([]string, []string) {
+	var header, description []string
+	inDescription := false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "mf-URL:") {
+			continue
+		}
+		lower := strings.ToLower(strings.TrimLeft(line, "# "))
+		if lower == "about the job" {
+			inDescription = true
+			continue
+		}
+		if inDescription {
+			if containsFold(linkedInDescriptionEnd, lower) {
+				break
+			}
+			if line != "" || (len(description) > 0 && description[len(description)-1] != "") {
+				description = append(description, line)
+			}
+			continue
+		}
+		// Headings rendered from HTML keep their Markdown markers
+		if line = strings.TrimSpace(strings.TrimLeft(line, "#")); line != "" {
+			header = append(header, line)
+		}
+	}
+	return header, description
+}
+
+// linkedInIsNoise reports whether every "·" part of a line is a badge, button
+// label or applicant count
+func linkedInIsNoise(line string) bool {
+	for _, part := range strings.Split(line, "·") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" || linkedInAgoPattern.MatchString(part) {
+			continue
+		}
+		if containsFold(linkedInNoise, part) || linkedInApplicantsPattern.MatchString(part) || linkedInHasNoisePrefix(part) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// linkedInHasNoisePrefix reports whether part starts with a label that has a variable tail
+func linkedInHasNoisePrefix(part string) bool {
+	for _, prefix := range linkedInNoisePrefixes {
+		if strings.HasPrefix(part, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// linkedInIsPill reports whether a line only holds workplace or job type pills
+func linkedInIsPill(line string) bool {
+	pills := linkedInPills(line)
+	return len(pills) > 0 && len(pills) == len(strings.Fields(line))
+}
+
+// linkedInPills returns the lowercased workplace and job type labels on a line
+func linkedInPills(line string) []string {
+	lower := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(line)), ".")
+	for _, hint := range []string{"workplace type is ", "job type is "} {
+		if i := strings.Index(lower, hint); i != -1 {
+			return []string{strings.TrimSpace(lower[i+len(hint):])}
+		}
+	}
+
+	var pills []string
+	for _, word := range strings.Fields(strings.ReplaceAll(lower, "·", " ")) {
+		if _, ok := linkedInWorkplace[word]; ok {
+			pills = append(pills, word)
+		} else if _, ok := linkedInEmployment[word]; ok {
+			pills = append(pills, word)
+		} else {
+			return nil
+		}
+	}
+	return pills
+}
+
+// parseLinkedInPostedAgo converts "2 weeks ago" or "Reposted 3 days ago" into a date relative to now
+func parseLinkedInPostedAgo(text string, now time.Time) (time.Time, bool) {
+	match := linkedInAgoPattern.FindStringSubmatch(text)
+	if len(match) < 3 {
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch strings.ToLower(match[2]) {
+	case "day":
+		return today.AddDate(0, 0, -n), true
+	case "week":
+		return today.AddDate(0, 0, -7*n), true
+	case "month":
+		return today.AddDate(0, -n, 0), true
+	case "year":
+		return today.AddDate(-n, 0, 0), true
+	}
+	// Minutes and hours ago are today
+	return today, true
+}
+
+// containsFold reports whether values holds want, ignoring case
+func containsFold(values []string, want string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, want) {
+			return true
+		}
+	}
+	return false
+}
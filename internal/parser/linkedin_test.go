@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"track-my-job-apps/internal/models"
+)
+
+func TestParseLinkedInJobCopiedText(t *testing.T) {
+	// Copied text with a logo line, promoted and reposted badges, and benefit lines
+	testData, err := os.ReadFile("testdata/linkedin.txt")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	result, err := ParseLinkedInJob(strings.Split(string(testData), "\n"), &models.JobApplication{})
+	if err != nil {
+		t.Fatalf("ParseLinkedInJob failed: %v", err)
+	}
+
+	assertParsed(t, result, map[string]string{
+		"Company":        "Northwind Traders",
+		"Position":       "Senior Backend Engineer, Payments",
+		"Location":       "Austin, TX",
+		"WorkplaceType":  "Hybrid",
+		"EmploymentType": "Full-time",
+		"SalaryRange":    "$165K/yr - $210K/yr",
+		"BoardJobId":     "4012345678",
+		"DatePosted":     time.Now().AddDate(0, 0, -14).Format("2006-01-02"),
+	})
+
+	if result.SalaryMin != 165000 || result.SalaryMax != 210000 || result.SalaryPeriod != models.ANNUAL {
+		t.Errorf("Expected annual salary 165000-210000 from the header, got %v-%v %s", result.SalaryMin, result.SalaryMax, result.SalaryPeriod)
+	}
+	if !strings.HasPrefix(result.Description, "Northwind is rebuilding its payments stack.") || strings.Contains(result.Description, "About the company") {
+		t.Errorf("Expected the description below 'About the job', got: %q", result.Description)
+	}
+}
+
+func TestParseLinkedInJobHTML(t *testing.T) {
+	testData, err := os.ReadFile("testdata/linkedin_html.txt")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	p, err := Detect(string(testData))
+	if err != nil || p.Name() != "linkedin" {
+		t.Fatalf("Expected linkedin parser to be detected, got %v, %v", p, err)
+	}
+	result, err := Run(p, string(testData))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	jobApp := result.JobApp
+
+	assertParsed(t, jobApp, map[string]string{
+		"Company":        "Contoso",
+		"Position":       "Staff Data Engineer",
+		"Location":       "Seattle, WA",
+		"WorkplaceType":  "Remote",
+		"EmploymentType": "Full-time",
+		"SalaryRange":    "$180K/yr - $220K/yr",
+		"CanonicalURL":   "https://www.linkedin.com/jobs/view/4098765432/",
+	})
+
+	if !strings.Contains(jobApp.Description, "Responsibilities:\n\n- Design streaming pipelines\n- Mentor engineers") {
+		t.Errorf("Expected the job details as Markdown, got: %q", jobApp.Description)
+	}
+	if result.Confidence["company"] != ConfidenceHigh || result.Confidence["position"] != ConfidenceHigh {
+		t.Errorf("Expected top card fields to be trusted, got %v", result.Confidence)
+	}
+}
+
+func TestParseLinkedInJobWithoutMetadataLine(t *testing.T) {
+	// Older copies put badges above the title and have no "·" line
+	testLines := []string{
+		"Promoted",
+		"Globex",
+		"Platform Engineer",
+		"Remote",
+		"Contract",
+		"€70 - €85 per hour",
+	}
+
+	result, err := ParseLinkedInJob(testLines, &models.JobApplication{})
+	if err != nil {
+		t.Fatalf("ParseLinkedInJob failed: %v", err)
+	}
+	if result.Company != "Globex" || result.Position != "Platform Engineer" {
+		t.Errorf("Expected Globex / Platform Engineer, got '%s' / '%s'", result.Company, result.Position)
+	}
+	if result.WorkplaceType != "Remote" || result.EmploymentType != "Contract" {
+		t.Errorf("Expected Remote contract, got '%s' / '%s'", result.WorkplaceType, result.EmploymentType)
+	}
+	if result.SalaryPeriod != models.HOURLY || result.SalaryCurrency != "EUR" {
+		t.Errorf("Expected hourly EUR salary, got %s %s", result.SalaryPeriod, result.SalaryCurrency)
+	}
+}
+
+func TestParseLinkedInJobNamesLikeBadges(t *testing.T) {
+	// Names that start or end with a button label are not badges
+	tests := []struct {
+		company  string
+		position string
+	}{
+		{"ShareThis", "Timeshare Sales Manager"},
+		{"Apply Digital", "Product Designer"},
+		{"Promoted Inc", "Account Executive, Save Desk"},
+	}
+	for _, test := range tests {
+		testLines := []string{
+			test.company + " logo",
+			test.company,
+			"Share",
+			test.position,
+			"Denver, CO · Posted 3 days ago · 12 applicants",
+			"Easy Apply",
+			"Save",
+		}
+
+		result, err := ParseLinkedInJob(testLines, &models.JobApplication{})
+		if err != nil {
+			t.Fatalf("ParseLinkedInJob failed: %v", err)
+		}
+		if result.Company != test.company || result.Position != test.position {
+			t.Errorf("Expected %s / %s, got '%s' / '%s'", test.company, test.position, result.Company, result.Position)
+		}
+	}
+}
+
+func TestParseLinkedInPostedAgo(t *testing.T) {
+	now := time.Date(2024, 6, 15, 18, 30, 0, 0, time.UTC)
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"Reposted 2 weeks ago", "2024-06-01"},
+		{"Austin, TX · 3 days ago · 57 applicants", "2024-06-12"},
+		{"1 month ago", "2024-05-15"},
+		{"12 hours ago", "2024-06-15"},
+	}
+	for _, test := range tests {
+		got, ok := parseLinkedInPostedAgo(test.text, now)
+		if !ok || got.Format("2006-01-02") != test.expected {
+			t.Errorf("parseLinkedInPostedAgo(%q) = %v, %v, expected %s", test.text, got, ok, test.expected)
+		}
+	}
+	if _, ok := parseLinkedInPostedAgo("Over 100 applicants", now); ok {
+		t.Errorf("Expected no date from an applicant count")
+	}
+}
@@ -46,9 +46,18 @@ func TestParseGreenhouseJob(t *testing.T) {
 		t.Errorf("Expected company '%s', got '%s'", expectedCompany, result.Company)
 	}
 
-	// Test 4: URL extraction in notes
-	if !strings.Contains(result.Notes, "https://job-boards.greenhouse.io/cloudflare/jobs/6467350?gh_jid=6467350&gh_src=5ylsd31") {
-		t.Errorf("Expected URL to be extracted in notes, got: %s", result.Notes)
+	// Test 4: Source URL, canonical URL and board job ID
+	if result.SourceURL != "https://job-boards.greenhouse.io/cloudflare/jobs/6467350?gh_jid=6467350&gh_src=5ylsd31" {
+		t.Errorf("Expected source URL to be extracted, got: %s", result.SourceURL)
+	}
+	if result.CanonicalURL != "https://job-boards.greenhouse.io/cloudflare/jobs/6467350?gh_jid=6467350" {
+		t.Errorf("Expected gh_src to be stripped from the canonical URL, got: %s", result.CanonicalURL)
+	}
+	if result.BoardJobId != "6467350" {
+		t.Errorf("Expected board job ID '6467350', got '%s'", result.BoardJobId)
+	}
+	if result.Notes != "" {
+		t.Errorf("Expected notes to be left empty, got: %s", result.Notes)
 	}
 
 	// Test 5: Status should be set to SUBMITTED
@@ -63,6 +72,11 @@ func TestParseGreenhouseJob(t *testing.T) {
 		t.Errorf("Expected date applied to be today (%s), got %s", today, appliedDate)
 	}
 
+	// Test 7: Full description is kept
+	if !strings.HasPrefix(result.Description, "About Us\n\nAt Cloudflare, we are on a mission") {
+		t.Errorf("Expected description to start with the About Us section, got: %.80s", result.Description)
+	}
+
 	// Print all extracted data for manual verification
 	t.Logf("Extracted Job Application:")
 	t.Logf("  Company: %s", result.Company)
@@ -71,7 +85,7 @@ func TestParseGreenhouseJob(t *testing.T) {
 	t.Logf("  Salary Range: %s", result.SalaryRange)
 	t.Logf("  Status: %s", result.Status)
 	t.Logf("  Date Applied: %s", result.DateApplied.Time.Format("2006-01-02"))
-	t.Logf("  Notes: %s", result.Notes)
+	t.Logf("  Source URL: %s", result.SourceURL)
 }
 
 func TestParseGreenhouseJob2(t *testing.T) {
@@ -111,9 +125,12 @@ func TestParseGreenhouseJob2(t *testing.T) {
 		t.Errorf("Expected company '%s', got '%s'", expectedCompany, result.Company)
 	}
 
-	// Test 4: URL extraction in notes
-	if !strings.Contains(result.Notes, "https://job-boards.greenhouse.io/checkr/jobs/7078460") {
-		t.Errorf("Expected URL to be extracted in notes, got: %s", result.Notes)
+	// Test 4: Source URL and board job ID
+	if result.SourceURL != "https://job-boards.greenhouse.io/checkr/jobs/7078460" {
+		t.Errorf("Expected source URL to be extracted, got: %s", result.SourceURL)
+	}
+	if result.BoardJobId != "7078460" {
+		t.Errorf("Expected board job ID '7078460', got '%s'", result.BoardJobId)
 	}
 
 	// Test 5: Status should be set to SUBMITTED
@@ -136,7 +153,7 @@ func TestParseGreenhouseJob2(t *testing.T) {
 	t.Logf("  Salary Range: %s", result.SalaryRange)
 	t.Logf("  Status: %s", result.Status)
 	t.Logf("  Date Applied: %s", result.DateApplied.Time.Format("2006-01-02"))
-	t.Logf("  Notes: %s", result.Notes)
+	t.Logf("  Source URL: %s", result.SourceURL)
 }
 
 func TestParseGreenhouseJob3(t *testing.T) {
@@ -176,9 +193,15 @@ func TestParseGreenhouseJob3(t *testing.T) {
 		t.Errorf("Expected company '%s', got '%s'", expectedCompany, result.Company)
 	}
 
-	// Test 4: URL extraction in notes
-	if !strings.Contains(result.Notes, "https://job-boards.greenhouse.io/figma/jobs/5552540004?gh_jid=5552540004&gh_src=28109e334us&source=LinkedIn") {
-		t.Errorf("Expected URL to be extracted in notes, got: %s", result.Notes)
+	// Test 4: Source URL, canonical URL and board job ID
+	if result.SourceURL != "https://job-boards.greenhouse.io/figma/jobs/5552540004?gh_jid=5552540004&gh_src=28109e334us&source=LinkedIn" {
+		t.Errorf("Expected source URL to be extracted, got: %s", result.SourceURL)
+	}
+	if result.CanonicalURL != "https://job-boards.greenhouse.io/figma/jobs/5552540004?gh_jid=5552540004" {
+		t.Errorf("Expected tracking parameters to be stripped from the canonical URL, got: %s", result.CanonicalURL)
+	}
+	if result.BoardJobId != "5552540004" {
+		t.Errorf("Expected board job ID '5552540004', got '%s'", result.BoardJobId)
 	}
 
 	// Test 5: Status should be set to SUBMITTED
@@ -201,7 +224,7 @@ func TestParseGreenhouseJob3(t *testing.T) {
 	t.Logf("  Salary Range: %s", result.SalaryRange)
 	t.Logf("  Status: %s", result.Status)
 	t.Logf("  Date Applied: %s", result.DateApplied.Time.Format("2006-01-02"))
-	t.Logf("  Notes: %s", result.Notes)
+	t.Logf("  Source URL: %s", result.SourceURL)
 }
 
 func TestParseGreenhouseJobWithSalary(t *testing.T) {
@@ -243,8 +266,16 @@ func TestParseGreenhouseJobWithSalary(t *testing.T) {
 	if !strings.Contains(result.SalaryRange, "$120,000 - $180,000") {
 		t.Errorf("Expected to find '$120,000 - $180,000' in salary range, got: %s", result.SalaryRange)
 	}
-	if !strings.Contains(result.SalaryRange, "$150,000 - $200,000") {
-		t.Errorf("Expected to find '$150,000 - $200,000' in salary range, got: %s", result.SalaryRange)
+	if !strings.Contains(result.SalaryRange, "$150,000-$200,000") {
+		t.Errorf("Expected to find '$150,000-$200,000' in salary range, got: %s", result.SalaryRange)
+	}
+
+	// Test structured salary spans both ranges
+	if result.SalaryMin != 120000 || result.SalaryMax != 200000 {
+		t.Errorf("Expected salary min/max 120000/200000, got %v/%v", result.SalaryMin, result.SalaryMax)
+	}
+	if result.SalaryCurrency != "USD" || result.SalaryPeriod != models.ANNUAL {
+		t.Errorf("Expected USD ANNUAL salary, got %s %s", result.SalaryCurrency, result.SalaryPeriod)
 	}
 
 	// Test other fields
@@ -257,8 +288,8 @@ func TestParseGreenhouseJobWithSalary(t *testing.T) {
 	if result.Company != "TestCompany" {
 		t.Errorf("Expected company 'TestCompany', got '%s'", result.Company)
 	}
-	if !strings.Contains(result.Notes, "https://example.com/job/123") {
-		t.Errorf("Expected URL in notes, got: %s", result.Notes)
+	if result.SourceURL != "https://example.com/job/123" {
+		t.Errorf("Expected source URL, got: %s", result.SourceURL)
 	}
 
 	// Log the results for verification
@@ -267,7 +298,32 @@ func TestParseGreenhouseJobWithSalary(t *testing.T) {
 	t.Logf("  Location: %s", result.Location)
 	t.Logf("  Company: %s", result.Company)
 	t.Logf("  Salary Range: %s", result.SalaryRange)
-	t.Logf("  Notes: %s", result.Notes)
+	t.Logf("  Source URL: %s", result.SourceURL)
+}
+
+func TestParseGreenhouseJobNestedMarkup(t *testing.T) {
+	testHTML := `
+	<div class="job__title">
+		<h1 class="section-header"><span class="badge">New</span> Staff Engineer &#8211; Developer&#8217;s Tools</h1>
+		<div class="job__location"><svg class="svg-icon"><path d="M0"></path></svg><div>Austin, <b>TX</b></div></div>
+	</div>
+	<img alt="Acme &amp; Co Logo" src="logo.png">
+	`
+
+	result, err := ParseGreenhouseJob(strings.Split(testHTML, "\n"), &models.JobApplication{})
+	if err != nil {
+		t.Fatalf("ParseGreenhouseJob failed: %v", err)
+	}
+
+	if result.Position != "Staff Engineer – Developer’s Tools" {
+		t.Errorf("Expected decoded position without the badge, got '%s'", result.Position)
+	}
+	if result.Location != "Austin, TX" {
+		t.Errorf("Expected location 'Austin, TX', got '%s'", result.Location)
+	}
+	if result.Company != "Acme & Co" {
+		t.Errorf("Expected company 'Acme & Co', got '%s'", result.Company)
+	}
 }
 
 func TestCleanHTMLTags(t *testing.T) {
@@ -295,6 +351,14 @@ func TestCleanHTMLTags(t *testing.T) {
 			input:    "  <span>  Whitespace  </span>  ",
 			expected: "Whitespace",
 		},
+		{
+			input:    "<h1>Engineer&#8217;s &mdash; <em>Role</em>&#x21;</h1>",
+			expected: "Engineer’s — Role!",
+		},
+		{
+			input:    "<div>Denver,\n\t\tColorado</div>",
+			expected: "Denver, Colorado",
+		},
 	}
 
 	for _, test := range tests {
@@ -344,3 +408,31 @@ func TestParseLinkedInJob(t *testing.T) {
 	t.Logf("  Location: %s", result.Location)
 	t.Logf("  Workplace Type: %s", result.WorkplaceType)
 }
+
+// assertParsed checks the parsed text fields named in want, dates as YYYY-MM-DD
+func assertParsed(t *testing.T, result *models.JobApplication, want map[string]string) {
+	t.Helper()
+	actual := map[string]string{
+		"Company":        result.Company,
+		"Position":       result.Position,
+		"Location":       result.Location,
+		"Department":     result.Department,
+		"EmploymentType": result.EmploymentType,
+		"WorkplaceType":  result.WorkplaceType,
+		"SalaryRange":    result.SalaryRange,
+		"SourceURL":      result.SourceURL,
+		"CanonicalURL":   result.CanonicalURL,
+		"BoardJobId":     result.BoardJobId,
+		"RequisitionId":  result.RequisitionId,
+		"DatePosted":     result.DatePosted.Time.Format("2006-01-02"),
+	}
+	for field, expected := range want {
+		got, ok := actual[field]
+		if !ok {
+			t.Fatalf("assertParsed does not know the field %s", field)
+		}
+		if got != expected {
+			t.Errorf("Expected %s '%s', got '%s'", field, expected, got)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"track-my-job-apps/internal/models"
+)
+
+// ErrUnsupportedSource is returned when no registered parser can handle the input
+var ErrUnsupportedSource = errors.New("unsupported source")
+
+// ParseFunc is the signature shared by the board-specific parse functions
+type ParseFunc func(lines []string, jobApp *models.JobApplication) (*models.JobApplication, error)
+
+// Parser turns the text copied from a job board into a job application
+type Parser interface {
+	// Name is the platform value accepted by App.TrackJobApp
+	Name() string
+	// MatchesURL reports whether the source URL belongs to the job board
+	MatchesURL(sourceURL string) bool
+	// MatchesContent reports whether the page content carries the job board's markers
+	MatchesContent(content string) bool
+	// Parse extracts the job application from the copied lines
+	Parse(lines []string, jobApp *models.JobApplication) (*models.JobApplication, error)
+}
+
+// board is a Parser identified by the hosts it is served from and markers found in its pages
+type board struct {
+	name    string
+	hosts   []string // matched against the host and path of the source URL
+	markers []string // matched against the raw page content
+	parse   ParseFunc
+	assess  func(content string, result *ParseResult)
+}
+
+func (b *board) Name() string {
+	return b.name
+}
+
+func (b *board) Parse(lines []string, jobApp *models.JobApplication) (*models.JobApplication, error) {
+	return b.parse(lines, jobApp)
+}
+
+func (b *board) Assess(content string, result *ParseResult) {
+	if b.assess != nil {
+		b.assess(content, result)
+	}
+}
+
+func (b *board) MatchesURL(sourceURL string) bool {
+	hostPath := urlHostPath(sourceURL)
+	if hostPath == "" {
+		return false
+	}
+	for _, host := range b.hosts {
+		if strings.Contains(hostPath, host) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *board) MatchesContent(content string) bool {
+	for _, marker := range b.markers {
+		if strings.Contains(content, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	registry  []Parser
+	fallbacks []Parser
+)
+
+// Register adds a parser to the registry. Boards call this from init.
+func Register(p Parser) {
+	mustBeUnregistered(p.Name())
+	registry = append(registry, p)
+}
+
+// RegisterFallback adds a generic parser that Detect only tries after every
+// board-specific parser failed to match
+func RegisterFallback(p Parser) {
+	mustBeUnregistered(p.Name())
+	fallbacks = append(fallbacks, p)
+}
+
+func mustBeUnregistered(name string) {
+	if _, exists := Lookup(name); exists {
+		panic(fmt.Sprintf("parser: %q registered twice", name))
+	}
+}
+
+// Parsers returns the registered parsers in registration order, fallbacks last
+func Parsers() []Parser {
+	parsers := append([]Parser(nil), registry...)
+	return append(parsers, fallbacks...)
+}
+
+// Lookup returns the parser registered under the given platform name
+func Lookup(name string) (Parser, bool) {
+	for _, p := range Parsers() {
+		if p.Name() == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Detect picks a parser for the copied content. The mf-URL line appended by
+// the Chrome extension is checked against every board first, then page
+// markers, then the generic fallback parsers.
+func Detect(content string) (Parser, error) {
+	sourceURL := ExtractSourceURL(content)
+
+	for _, p := range registry {
+		if p.MatchesURL(sourceURL) {
+			return p, nil
+		}
+	}
+	for _, p := range registry {
+		if p.MatchesContent(content) {
+			return p, nil
+		}
+	}
+	for _, p := range fallbacks {
+		if p.MatchesURL(sourceURL) || p.MatchesContent(content) {
+			return p, nil
+		}
+	}
+
+	if sourceURL != "" {
+		return nil, fmt.Errorf("%w: no parser for %s", ErrUnsupportedSource, sourceURL)
+	}
+	return nil, fmt.Errorf("%w: could not recognize the job board from the pasted content", ErrUnsupportedSource)
+}
+
+// Select returns the parser for an explicit platform name, or detects one
+// from the content when platform is empty or "auto"
+func Select(platform string, content string) (Parser, error) {
+	platform = strings.ToLower(strings.TrimSpace(platform))
+	if platform == "" || platform == "auto" {
+		return Detect(content)
+	}
+
+	p, ok := Lookup(platform)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown platform %q", ErrUnsupportedSource, platform)
+	}
+	return p, nil
+}
+
+var sourceURLPattern = regexp.MustCompile(`mf-URL:\s*(\S+)`)
+
+// ExtractSourceURL returns the URL from the "mf-URL:" line appended by the Chrome extension
+func ExtractSourceURL(content string) string {
+	if match := sourceURLPattern.FindStringSubmatch(content); len(match) > 1 {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
+}
+
+// urlHostPath returns the lowercased host and path of a URL without the "www." prefix
+func urlHostPath(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	return host + strings.ToLower(u.Path)
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestDetectGreenhouseFromSourceURL(t *testing.T) {
+	for _, file := range []string{"../../scratch.txt", "../../scratch2.txt", "../../scratch3.txt"} {
+		testData, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read test data: %v", err)
+		}
+
+		p, err := Detect(string(testData))
+		if err != nil {
+			t.Fatalf("Detect(%s) failed: %v", file, err)
+		}
+		if p.Name() != "greenhouse" {
+			t.Errorf("Expected greenhouse parser for %s, got '%s'", file, p.Name())
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "linkedin url",
+			content:  "HTML: <div></div>\n'mf-URL: https://www.linkedin.com/jobs/view/4012345678/",
+			expected: "linkedin",
+		},
+		{
+			name:     "linkedin copied text",
+			content:  "TestCompany Inc\nSenior Developer\nMatches your job preferences, workplace type is Remote",
+			expected: "linkedin",
+		},
+		{
+			name:     "greenhouse markup without url",
+			content:  `<div class="job__title"><h1>Engineer</h1></div>`,
+			expected: "greenhouse",
+		},
+		{
+			name:     "url wins over markers",
+			content:  "Easy Apply\n<div class=\"job__title\"></div>\nmf-URL: https://boards.greenhouse.io/acme/jobs/123",
+			expected: "greenhouse",
+		},
+	}
+
+	for _, test := range tests {
+		p, err := Detect(test.content)
+		if err != nil {
+			t.Errorf("%s: Detect failed: %v", test.name, err)
+			continue
+		}
+		if p.Name() != test.expected {
+			t.Errorf("%s: expected parser '%s', got '%s'", test.name, test.expected, p.Name())
+		}
+	}
+}
+
+func TestDetectUnsupportedSource(t *testing.T) {
+	inputs := []string{
+		"HTML: <h1>Engineer</h1>\n'mf-URL: https://careers.example.com/jobs/42",
+		"just some text",
+	}
+
+	for _, input := range inputs {
+		if _, err := Detect(input); !errors.Is(err, ErrUnsupportedSource) {
+			t.Errorf("Expected ErrUnsupportedSource for %q, got %v", input, err)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	p, err := Select("Greenhouse", "")
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	if p.Name() != "greenhouse" {
+		t.Errorf("Expected greenhouse parser, got '%s'", p.Name())
+	}
+
+	if _, err := Select("monster", ""); !errors.Is(err, ErrUnsupportedSource) {
+		t.Errorf("Expected ErrUnsupportedSource for unknown platform, got %v", err)
+	}
+
+	p, err = Select("auto", "mf-URL: https://www.linkedin.com/jobs/view/1")
+	if err != nil {
+		t.Fatalf("Select auto failed: %v", err)
+	}
+	if p.Name() != "linkedin" {
+		t.Errorf("Expected linkedin parser, got '%s'", p.Name())
+	}
+}
+
+func TestExtractSourceURL(t *testing.T) {
+	content := "HTML: <div></div>\n'mf-URL: https://jobs.lever.co/acme/0b1c2d3e \n"
+	expected := "https://jobs.lever.co/acme/0b1c2d3e"
+	if got := ExtractSourceURL(content); got != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, got)
+	}
+	if got := ExtractSourceURL("no url here"); got != "" {
+		t.Errorf("Expected empty URL, got '%s'", got)
+	}
+}
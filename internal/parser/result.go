@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"strings"
+
+	"track-my-job-apps/internal/models"
+)
+
+// Confidence levels reported for parsed fields
+const (
+	ConfidenceHigh     = 1.0
+	ConfidenceMedium   = 0.7
+	ConfidenceLow      = 0.4
+	ConfidenceNotFound = 0.0
+)
+
+// reviewThreshold is the confidence below which the review screen highlights a field
+const reviewThreshold = 0.8
+
+// assessedFields maps the JSON field names the frontend uses to their values
+var assessedFields = []struct {
+	name    string
+	value   func(*models.JobApplication) string
+	warning string
+}{
+	{"company", func(app *models.JobApplication) string { return app.Company }, "no company found"},
+	{"position", func(app *models.JobApplication) string { return app.Position }, "no position found"},
+	{"location", func(app *models.JobApplication) string { return app.Location }, "no location found"},
+	{"salaryRange", func(app *models.JobApplication) string { return app.SalaryRange }, "no salary found"},
+	{"workplaceType", func(app *models.JobApplication) string { return app.WorkplaceType }, ""},
+}
+
+// ParseResult wraps a parsed application with how much each field can be trusted
+type ParseResult struct {
+	JobApp *models.JobApplication `json:"jobApp"`
+	// Parser is the platform name of the parser that produced the application
+	Parser string `json:"parser"`
+	// Confidence is keyed by the application's JSON field names, from 0 (not found) to 1
+	Confidence map[string]float64 `json:"confidence"`
+	Warnings   []string           `json:"warnings"`
+	// NeedsReview lists the fields the review screen should highlight
+	NeedsReview []string `json:"needsReview"`
+}
+
+// Assessor is implemented by parsers that know which fields they had to guess
+type Assessor interface {
+	Assess(content string, result *ParseResult)
+}
+
+// Run parses content with p and assesses the result
+func Run(p Parser, content string) (*ParseResult, error) {
+	lines := strings.Split(content, "\n")
+	jobApp, err := p.Parse(lines, &models.JobApplication{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ParseResult{
+		JobApp:     jobApp,
+		Parser:     p.Name(),
+		Confidence: map[string]float64{},
+		Warnings:   []string{},
+	}
+	result.assessPresence()
+	if assessor, ok := p.(Assessor); ok {
+		assessor.Assess(content, result)
+	}
+	result.assessSalary()
+	result.NeedsReview = result.fieldsToReview()
+
+	return result, nil
+}
+
+// Flag lowers the confidence of a field that was found, recording why
+func (r *ParseResult) Flag(field string, confidence float64, warning string) {
+	current := r.Confidence[field]
+	if current == ConfidenceNotFound || confidence >= current {
+		return
+	}
+	r.Confidence[field] = confidence
+	if warning != "" {
+		r.Warnings = append(r.Warnings, warning)
+	}
+}
+
+// fieldsToReview lists the fields whose confidence is below the review threshold
+func (r *ParseResult) fieldsToReview() []string {
+	fields := []string{}
+	for _, field := range assessedFields {
+		if r.Confidence[field.name] < reviewThreshold {
+			fields = append(fields, field.name)
+		}
+	}
+	return fields
+}
+
+// assessPresence trusts every field that was found and warns about missing ones
+func (r *ParseResult) assessPresence() {
+	for _, field := range assessedFields {
+		if strings.TrimSpace(field.value(r.JobApp)) == "" {
+			r.Confidence[field.name] = ConfidenceNotFound
+			if field.warning != "" {
+				r.Warnings = append(r.Warnings, field.warning)
+			}
+			continue
+		}
+		r.Confidence[field.name] = ConfidenceHigh
+	}
+}
+
+// assessSalary flags salary text that could not be turned into structured fields
+func (r *ParseResult) assessSalary() {
+	if r.JobApp.SalaryRange == "" {
+		return
+	}
+	if r.JobApp.SalaryMin == 0 && r.JobApp.SalaryMax == 0 {
+		r.Flag("salaryRange", ConfidenceLow, "salary text found but could not be parsed")
+		return
+	}
+	// Amounts between hourly and annual pay with no period may be monthly
+	// pay, or a bonus or budget, and cannot be annualized
+	if r.JobApp.SalaryPeriod == "" {
+		r.Flag("salaryRange", ConfidenceLow, "salary has no pay period, it may be a bonus or budget")
+		return
+	}
+	if len(ExtractSalaries(r.JobApp.SalaryRange)) > 1 {
+		r.Flag("salaryRange", ConfidenceMedium, "multiple salary ranges found, using the overall span")
+	}
+}
+
+// assessCompany flags a company name taken from a logo or, when it equals
+// the account slug in the job board URL, from the URL rather than page text
+func assessCompany(r *ParseResult, urlAccount string) {
+	if r.JobApp.Company != "" && r.JobApp.Company == urlAccount {
+		r.Flag("company", ConfidenceLow, "company inferred from the job board URL")
+		return
+	}
+	r.Flag("company", ConfidenceMedium, "company inferred from logo alt text")
+}
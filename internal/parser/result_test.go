@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRunGreenhouse(t *testing.T) {
+	testData, err := os.ReadFile("../../scratch2.txt")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	p, err := Detect(string(testData))
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	result, err := Run(p, string(testData))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.Parser != "greenhouse" {
+		t.Errorf("Expected parser 'greenhouse', got '%s'", result.Parser)
+	}
+	if result.JobApp.Company != "Checkr" {
+		t.Errorf("Expected company 'Checkr', got '%s'", result.JobApp.Company)
+	}
+	if result.Confidence["position"] != ConfidenceHigh {
+		t.Errorf("Expected high position confidence, got %v", result.Confidence["position"])
+	}
+	if result.Confidence["company"] != ConfidenceMedium {
+		t.Errorf("Expected medium company confidence, got %v", result.Confidence["company"])
+	}
+	if !containsString(result.Warnings, "company inferred from logo alt text") {
+		t.Errorf("Expected logo warning, got %v", result.Warnings)
+	}
+	if !containsString(result.NeedsReview, "company") || containsString(result.NeedsReview, "position") {
+		t.Errorf("Expected only low-confidence fields to need review, got %v", result.NeedsReview)
+	}
+}
+
+func TestRunReportsMissingFields(t *testing.T) {
+	content := `<div class="job__title"><h1>Engineer</h1></div>
+'mf-URL: https://job-boards.greenhouse.io/acme/jobs/123`
+
+	p, _ := Lookup("greenhouse")
+	result, err := Run(p, content)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.JobApp.Company != "acme" {
+		t.Errorf("Expected company from URL 'acme', got '%s'", result.JobApp.Company)
+	}
+	if result.Confidence["company"] != ConfidenceLow {
+		t.Errorf("Expected low company confidence, got %v", result.Confidence["company"])
+	}
+	for _, warning := range []string{"company inferred from the job board URL", "no salary found", "no location found"} {
+		if !containsString(result.Warnings, warning) {
+			t.Errorf("Expected warning '%s', got %v", warning, result.Warnings)
+		}
+	}
+	if result.Confidence["salaryRange"] != ConfidenceNotFound {
+		t.Errorf("Expected salary not found, got %v", result.Confidence["salaryRange"])
+	}
+}
+
+func TestRunFlagsSalaryWithoutPeriod(t *testing.T) {
+	p, _ := Lookup("greenhouse")
+	for _, test := range []struct {
+		content string
+		found   bool
+	}{
+		// A lone budget is not a salary
+		{`<div class="job__title"><h1>Engineer</h1></div><p>Includes a $2,000 learning budget.</p>`, false},
+		// A range without a period is kept for the user to check
+		{`<div class="job__title"><h1>Engineer</h1></div><p>A $2,000 - $3,000 signing bonus.</p>`, true},
+	} {
+		result, err := Run(p, test.content)
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if !test.found {
+			if result.JobApp.SalaryRange != "" {
+				t.Errorf("Expected no salary from %q, got '%s'", test.content, result.JobApp.SalaryRange)
+			}
+			continue
+		}
+		if result.JobApp.SalaryPeriod != "" || !containsString(result.NeedsReview, "salaryRange") {
+			t.Errorf("Expected a period-less salary to need review, got period '%s', review %v", result.JobApp.SalaryPeriod, result.NeedsReview)
+		}
+		if !containsString(result.Warnings, "salary has no pay period, it may be a bonus or budget") {
+			t.Errorf("Expected a warning about the missing period, got %v", result.Warnings)
+		}
+	}
+}
+
+func containsString(values []string, want string) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
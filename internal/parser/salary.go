@@ -0,0 +1,294 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"track-my-job-apps/internal/models"
+)
+
+// SalaryMatch is one salary range found in posting text
+type SalaryMatch struct {
+	Min      float64
+	Max      float64
+	Currency string
+	Period   models.PayPeriod
+	Text     string
+
+	// explicit is set for ranges and amounts posted with a pay period
+	explicit bool
+}
+
+const (
+	salaryCurrencyPrefix = `(?:US|CA|C|AU|A|NZ)?\$|€|£|¥|₹|(?:USD|EUR|GBP|CAD|AUD|NZD|CHF|INR|JPY)\s?`
+	salaryCurrencySuffix = `\s?(?:(?:USD|EUR|GBP|CAD|AUD|NZD|CHF|INR|JPY)\b|€)`
+	salaryNumber         = `\d{1,3}(?:[.,]\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d{1,2})?`
+	salaryPeriod         = `\s*(?:(?:/|per\b|\ban?\b)\s*(?:hour|hr|year|yr|annum|month|mo|week|wk|day)s?\b|hourly\b|annually\b|yearly\b|monthly\b|weekly\b|daily\b)`
+)
+
+var salaryPattern = regexp.MustCompile(`(?i)` +
+	`(?P<c1>` + salaryCurrencyPrefix + `)?(?P<n1>` + salaryNumber + `)(?P<k1>\s?k\b)?(?P<s1>` + salaryCurrencySuffix + `)?(?P<p1>` + salaryPeriod + `)?` +
+	`(?:\s*(?:-|–|—|\bto\b|\band\b)\s*` +
+	`(?P<c2>` + salaryCurrencyPrefix + `)?(?P<n2>` + salaryNumber + `)(?P<k2>\s?k\b)?(?P<s2>` + salaryCurrencySuffix + `)?)?` +
+	`(?P<p2>` + salaryPeriod + `)?`)
+
+var htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+var currencyCodes = map[string]string{
+	"$":   "USD",
+	"US$": "USD",
+	"CA$": "CAD",
+	"C$":  "CAD",
+	"AU$": "AUD",
+	"A$":  "AUD",
+	"NZ$": "NZD",
+	"€":   "EUR",
+	"£":   "GBP",
+	"¥":   "JPY",
+	"₹":   "INR",
+}
+
+// ExtractSalaries finds every salary range in text, understanding forms like
+// "$120K–$150K/yr", "€60.000", "$55/hr" and "184,000 USD - 287,500 USD"
+func ExtractSalaries(text string) []SalaryMatch {
+	var matches []SalaryMatch
+	names := salaryPattern.SubexpNames()
+	for _, indexes := range salaryPattern.FindAllStringSubmatchIndex(text, -1) {
+		groups := map[string]string{}
+		for i, name := range names {
+			if name != "" && indexes[2*i] >= 0 {
+				groups[name] = strings.TrimSpace(text[indexes[2*i]:indexes[2*i+1]])
+			}
+		}
+		if match, ok := newSalaryMatch(groups); ok {
+			match.Text = strings.TrimSpace(text[indexes[0]:indexes[1]])
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+func newSalaryMatch(groups map[string]string) (SalaryMatch, bool) {
+	currency := firstNonEmpty(groups["c1"], groups["s1"], groups["c2"], groups["s2"])
+	if currency == "" {
+		return SalaryMatch{}, false
+	}
+
+	low, ok := parseAmount(groups["n1"])
+	if !ok {
+		return SalaryMatch{}, false
+	}
+	high := low
+	if groups["n2"] != "" {
+		if high, ok = parseAmount(groups["n2"]); !ok {
+			return SalaryMatch{}, false
+		}
+	}
+
+	// "$120-150K" carries the multiplier on the upper bound only
+	if groups["k1"] != "" {
+		low *= 1000
+	}
+	if groups["k2"] != "" {
+		high *= 1000
+		if groups["k1"] == "" && low < 1000 {
+			low *= 1000
+		}
+	} else if groups["n2"] == "" {
+		high = low
+	}
+
+	if low <= 0 || high < low || high > 10*low {
+		return SalaryMatch{}, false
+	}
+
+	period := parsePeriod(firstNonEmpty(groups["p2"], groups["p1"]))
+	if period == "" {
+		period = inferPeriod(high)
+	}
+	// A lone amount without a period is only a salary when it looks like one
+	if groups["n2"] == "" && groups["p1"] == "" && groups["p2"] == "" && (high < 10000 || high > 2000000) {
+		return SalaryMatch{}, false
+	}
+
+	return SalaryMatch{
+		Min:      low,
+		Max:      high,
+		Currency: currencyCode(currency),
+		Period:   period,
+		explicit: groups["n2"] != "" || groups["p1"] != "" || groups["p2"] != "",
+	}, true
+}
+
+// parseAmount reads a number that may use "," or "." as the thousands or decimal separator
+func parseAmount(number string) (float64, bool) {
+	lastDot := strings.LastIndex(number, ".")
+	lastComma := strings.LastIndex(number, ",")
+
+	var decimalSep string
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		// Both present: whichever comes last is the decimal separator
+		decimalSep = "."
+		if lastComma > lastDot {
+			decimalSep = ","
+		}
+	case lastDot >= 0 && !isThousandsGrouped(number, "."):
+		decimalSep = "."
+	case lastComma >= 0 && !isThousandsGrouped(number, ","):
+		decimalSep = ","
+	}
+
+	var normalized strings.Builder
+	for _, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+			normalized.WriteRune(r)
+		case string(r) == decimalSep:
+			normalized.WriteByte('.')
+		}
+	}
+
+	amount, err := strconv.ParseFloat(normalized.String(), 64)
+	return amount, err == nil
+}
+
+// isThousandsGrouped reports whether every group after sep has exactly three digits
+func isThousandsGrouped(number string, sep string) bool {
+	groups := strings.Split(number, sep)
+	for _, group := range groups[1:] {
+		if len(group) != 3 {
+			return false
+		}
+	}
+	return true
+}
+
+func parsePeriod(text string) models.PayPeriod {
+	text = strings.ToLower(text)
+	switch {
+	case text == "":
+		return ""
+	case strings.Contains(text, "hour") || strings.Contains(text, "hr"):
+		return models.HOURLY
+	case strings.Contains(text, "da"):
+		return models.DAILY
+	case strings.Contains(text, "week") || strings.Contains(text, "wk"):
+		return models.WEEKLY
+	case strings.Contains(text, "mo"):
+		return models.MONTHLY
+	default:
+		return models.ANNUAL
+	}
+}
+
+// inferPeriod guesses the pay period of an amount posted without one
+func inferPeriod(amount float64) models.PayPeriod {
+	switch {
+	case amount <= 500:
+		return models.HOURLY
+	case amount >= 10000:
+		return models.ANNUAL
+	}
+	return ""
+}
+
+func currencyCode(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if code, ok := currencyCodes[currency]; ok {
+		return code
+	}
+	return currency
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// applySalary stores the salaries found in text on the application. The raw
+// text of every match is kept in SalaryRange; the structured fields span all
+// ranges sharing the first match's currency and period, so geo-tiered postings
+// report their lowest minimum and highest maximum. Lone amounts such as a
+// "$2,000 learning stipend" are ignored when the text also has real ranges.
+func applySalary(text string, jobApp *models.JobApplication) bool {
+	// React splits "$149,000<!-- --> - <!-- -->$350,000" with empty comments
+	text = htmlCommentPattern.ReplaceAllString(text, "")
+
+	matches := preferExplicit(ExtractSalaries(text))
+	if len(matches) == 0 {
+		return false
+	}
+
+	var rawTexts []string
+	for _, match := range matches {
+		rawTexts = append(rawTexts, match.Text)
+	}
+	jobApp.SalaryRange = strings.Join(rawTexts, ", ")
+	setSalaryFields(matches, jobApp)
+	return true
+}
+
+// ReparseSalary recomputes the structured salary fields from a SalaryRange
+// the user edited, keeping the text as typed
+func ReparseSalary(jobApp *models.JobApplication) {
+	jobApp.SalaryMin, jobApp.SalaryMax = 0, 0
+	jobApp.SalaryCurrency, jobApp.SalaryPeriod = "", ""
+	if matches := preferExplicit(ExtractSalaries(jobApp.SalaryRange)); len(matches) > 0 {
+		setSalaryFields(matches, jobApp)
+	}
+}
+
+// preferExplicit drops lone amounts when any range or amount with a period
+// was found, along with ranges repeated elsewhere in the posting
+func preferExplicit(matches []SalaryMatch) []SalaryMatch {
+	var explicit, unique []SalaryMatch
+	for _, match := range matches {
+		if match.explicit {
+			explicit = append(explicit, match)
+		}
+	}
+	if len(explicit) > 0 {
+		matches = explicit
+	}
+
+	for _, match := range matches {
+		duplicate := false
+		for _, seen := range unique {
+			if seen.Min == match.Min && seen.Max == match.Max && seen.Currency == match.Currency && seen.Period == match.Period {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			unique = append(unique, match)
+		}
+	}
+	return unique
+}
+
+// setSalaryFields fills the structured salary fields from the matches
+func setSalaryFields(matches []SalaryMatch, jobApp *models.JobApplication) {
+	first := matches[0]
+	jobApp.SalaryMin = first.Min
+	jobApp.SalaryMax = first.Max
+	jobApp.SalaryCurrency = first.Currency
+	jobApp.SalaryPeriod = first.Period
+
+	for _, match := range matches[1:] {
+		if match.Currency != first.Currency || match.Period != first.Period {
+			continue
+		}
+		if match.Min < jobApp.SalaryMin {
+			jobApp.SalaryMin = match.Min
+		}
+		if match.Max > jobApp.SalaryMax {
+			jobApp.SalaryMax = match.Max
+		}
+	}
+}
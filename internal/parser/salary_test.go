@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"testing"
+
+	"track-my-job-apps/internal/models"
+)
+
+func TestExtractSalaries(t *testing.T) {
+	tests := []struct {
+		input    string
+		min      float64
+		max      float64
+		currency string
+		period   models.PayPeriod
+	}{
+		{"$120K–$150K/yr", 120000, 150000, "USD", models.ANNUAL},
+		{"$120K/yr - $150K/yr", 120000, 150000, "USD", models.ANNUAL},
+		{"$120-150K", 120000, 150000, "USD", models.ANNUAL},
+		{"€60.000", 60000, 60000, "EUR", models.ANNUAL},
+		{"€1.234,50 per month", 1234.5, 1234.5, "EUR", models.MONTHLY},
+		{"$55/hr", 55, 55, "USD", models.HOURLY},
+		{"$42.50 - $48 an hour", 42.5, 48, "USD", models.HOURLY},
+		{"£45,000 to £55,000 per annum", 45000, 55000, "GBP", models.ANNUAL},
+		{"CA$120K – CA$150K", 120000, 150000, "CAD", models.ANNUAL},
+		{"184,000 USD - 287,500 USD", 184000, 287500, "USD", models.ANNUAL},
+		{"between $163,200 and $223,200 per year", 163200, 223200, "USD", models.ANNUAL},
+	}
+
+	for _, test := range tests {
+		matches := ExtractSalaries(test.input)
+		if len(matches) != 1 {
+			t.Errorf("ExtractSalaries('%s') found %d matches, expected 1: %+v", test.input, len(matches), matches)
+			continue
+		}
+		match := matches[0]
+		if match.Min != test.min || match.Max != test.max || match.Currency != test.currency || match.Period != test.period {
+			t.Errorf("ExtractSalaries('%s') = %v-%v %s %s, expected %v-%v %s %s",
+				test.input, match.Min, match.Max, match.Currency, match.Period,
+				test.min, test.max, test.currency, test.period)
+		}
+	}
+}
+
+func TestExtractSalariesIgnoresNonSalaries(t *testing.T) {
+	inputs := []string{
+		"Founded in 2012 with 401(k) matching",
+		"We raised $250,000,000 in our Series D",
+		"Team of 120 - 150 engineers",
+	}
+
+	for _, input := range inputs {
+		if matches := ExtractSalaries(input); len(matches) != 0 {
+			t.Errorf("ExtractSalaries('%s') = %+v, expected no matches", input, matches)
+		}
+	}
+}
+
+func TestApplySalaryGeoTiers(t *testing.T) {
+	text := `<p>NYC/SF: $180,000 - $220,000</p><p>Denver: $150,000<!-- --> - <!-- -->$190,000</p><p>Plus a $2,000 learning stipend.</p>`
+
+	jobApp := &models.JobApplication{}
+	if !applySalary(text, jobApp) {
+		t.Fatalf("Expected salary to be found")
+	}
+
+	if jobApp.SalaryMin != 150000 || jobApp.SalaryMax != 220000 {
+		t.Errorf("Expected salary span 150000-220000, got %v-%v", jobApp.SalaryMin, jobApp.SalaryMax)
+	}
+	if jobApp.SalaryCurrency != "USD" || jobApp.SalaryPeriod != models.ANNUAL {
+		t.Errorf("Expected USD ANNUAL, got %s %s", jobApp.SalaryCurrency, jobApp.SalaryPeriod)
+	}
+	if jobApp.SalaryRange != "$180,000 - $220,000, $150,000 - $190,000" {
+		t.Errorf("Unexpected raw salary text '%s'", jobApp.SalaryRange)
+	}
+}
+
+func TestReparseSalary(t *testing.T) {
+	jobApp := &models.JobApplication{SalaryRange: "€60 - €75 per hour", SalaryMin: 100000, SalaryMax: 120000, SalaryCurrency: "USD", SalaryPeriod: models.ANNUAL}
+	ReparseSalary(jobApp)
+	if jobApp.SalaryMin != 60 || jobApp.SalaryMax != 75 || jobApp.SalaryCurrency != "EUR" || jobApp.SalaryPeriod != models.HOURLY {
+		t.Errorf("Expected hourly EUR 60-75, got %v-%v %s %s", jobApp.SalaryMin, jobApp.SalaryMax, jobApp.SalaryCurrency, jobApp.SalaryPeriod)
+	}
+	if jobApp.SalaryRange != "€60 - €75 per hour" {
+		t.Errorf("Expected salary text to be kept, got '%s'", jobApp.SalaryRange)
+	}
+
+	jobApp.SalaryRange = "competitive"
+	ReparseSalary(jobApp)
+	if jobApp.SalaryMin != 0 || jobApp.SalaryMax != 0 || jobApp.SalaryCurrency != "" {
+		t.Errorf("Expected structured salary to be cleared, got %v-%v %s", jobApp.SalaryMin, jobApp.SalaryMax, jobApp.SalaryCurrency)
+	}
+}
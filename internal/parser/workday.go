@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"track-my-job-apps/internal/dom"
+	"track-my-job-apps/internal/models"
+)
+
+func init() {
+	Register(&board{
+		name:    "workday",
+		hosts:   []string{"myworkdayjobs.com", "myworkdaysite.com"},
+		markers: []string{`data-automation-id="jobPostingHeader"`},
+		parse:   ParseWorkdayJob,
+		assess: func(content string, result *ParseResult) {
+			assessCompany(result, workdayTenant(ExtractSourceURL(content)))
+			if workdayLocationsSummary.MatchString(result.JobApp.Location) {
+				result.Flag("location", ConfidenceLow, "only the number of locations was listed")
+			}
+		},
+	})
+}
+
+// workdayLocationsSummary matches the "3 Locations" placeholder Workday shows for multi-location postings
+var workdayLocationsSummary = regexp.MustCompile(`(?i)^\d+\s+locations?$`)
+
+// ParseWorkdayJob extracts job data from Workday (myworkdayjobs.com) HTML content.
+// Workday renders posting data in data-automation-id attributes rather than classes.
+func ParseWorkdayJob(lines []string, jobApp *models.JobApplication) (*models.JobApplication, error) {
+	// Join all lines into a single HTML string
+	htmlContent := strings.Join(lines, "\n")
+
+	doc, err := dom.Parse(htmlContent)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Extract job title from <h2 data-automation-id="jobPostingHeader">
+	jobApp.Position = doc.First(`[data-automation-id="jobPostingHeader"]`).Text()
+
+	// 2. Locations are listed one per <dd>, dropping the "N Locations" summary when the list is present
+	var locations []string
+	var summary string
+	for _, id := range []string{"locations", "additionalLocations"} {
+		for _, location := range workdayField(doc, id) {
+			if workdayLocationsSummary.MatchString(location) {
+				summary = location
+				continue
+			}
+			locations = append(locations, location)
+		}
+	}
+	if len(locations) > 0 {
+		jobApp.Location = strings.Join(locations, "; ")
+	} else {
+		jobApp.Location = summary
+	}
+
+	// 3. Time type, remote type and requisition ID
+	if timeType := workdayField(doc, "time"); len(timeType) > 0 {
+		jobApp.EmploymentType = timeType[0]
+	}
+	if remoteType := workdayField(doc, "remoteType"); len(remoteType) > 0 {
+		jobApp.WorkplaceType = remoteType[0]
+	}
+	if requisitionID := workdayField(doc, "requisitionId"); len(requisitionID) > 0 {
+		jobApp.RequisitionId = requisitionID[0]
+	}
+
+	// 4. Posted date is relative ("Posted 3 Days Ago")
+	if postedOn := workdayField(doc, "postedOn"); len(postedOn) > 0 {
+		if posted, ok := parseWorkdayPostedOn(postedOn[0], time.Now()); ok {
+			jobApp.DatePosted = models.DateOnly{Time: posted}
+		}
+	}
+
+	// 5. Keep the full description from <div data-automation-id="jobPostingDescription">
+	jobApp.Description = doc.First(`[data-automation-id="jobPostingDescription"]`).Markdown()
+
+	// 6. Find salary patterns like $100,000 - $150,000 or 184,000 USD - 287,500 USD
+	applySalary(doc.Text(), jobApp)
+
+	// 7. Record the "mf-URL:" source and board job ID
+	setSource(htmlContent, jobApp)
+
+	// 8. Company name comes from the header logo, or the Workday tenant in the URL
+	if logo := doc.First(`img[data-automation-id="logoImage"]`); logo != nil {
+		jobApp.Company = strings.TrimSpace(trimSuffixFold(logo.Attr("alt"), " logo"))
+	}
+	if jobApp.Company == "" {
+		jobApp.Company = workdayTenant(ExtractSourceURL(htmlContent))
+	}
+
+	jobApp.Status = models.SUBMITTED
+	jobApp.DateApplied = models.DateOnly{Time: time.Now()}
+
+	return jobApp, nil
+}
+
+// workdayField returns the <dd> values inside the element with the given data-automation-id
+func workdayField(doc *dom.Node, automationID string) []string {
+	return dom.Texts(doc.Find(`[data-automation-id="` + automationID + `"] dd`))
+}
+
+// parseWorkdayPostedOn converts "Posted Today", "Posted Yesterday" and
+// "Posted N Days Ago" (including "30+") into a date relative to now
+func parseWorkdayPostedOn(text string, now time.Time) (time.Time, bool) {
+	text = strings.TrimSpace(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(text)), "posted"))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch text {
+	case "today":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+
+	daysPattern := regexp.MustCompile(`^(\d+)\+?\s+days?\s+ago$`)
+	if match := daysPattern.FindStringSubmatch(text); len(match) > 1 {
+		days, err := strconv.Atoi(match[1])
+		if err != nil {
+			return time.Time{}, false
+		}
+		return today.AddDate(0, 0, -days), true
+	}
+	return time.Time{}, false
+}
+
+// workdayTenant returns the tenant from a <tenant>.wdN.myworkdayjobs.com URL
+func workdayTenant(sourceURL string) string {
+	u, err := url.Parse(sourceURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return strings.Split(u.Hostname(), ".")[0]
+}
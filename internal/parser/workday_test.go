@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"track-my-job-apps/internal/models"
+)
+
+func TestParseWorkdayJob(t *testing.T) {
+	// Read the test HTML data copied by the Chrome extension
+	testData, err := os.ReadFile("testdata/workday.txt")
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	lines := strings.Split(string(testData), "\n")
+	jobApp := &models.JobApplication{}
+
+	result, err := ParseWorkdayJob(lines, jobApp)
+	if err != nil {
+		t.Fatalf("ParseWorkdayJob failed: %v", err)
+	}
+
+	assertParsed(t, result, map[string]string{
+		"Company":        "NVIDIA",
+		"Position":       "Senior System Software Engineer - GPU Cloud",
+		"Location":       "US, CA, Santa Clara; US, WA, Redmond; US, TX, Austin",
+		"EmploymentType": "Full time",
+		"WorkplaceType":  "Hybrid",
+		"RequisitionId":  "JR1987654",
+		"BoardJobId":     "JR1987654",
+	})
+
+	if result.SalaryMin != 184000 || result.SalaryMax != 356500 || result.SalaryCurrency != "USD" {
+		t.Errorf("Expected salary 184000-356500 USD across levels, got %v-%v %s", result.SalaryMin, result.SalaryMax, result.SalaryCurrency)
+	}
+
+	expectedPosted := time.Now().AddDate(0, 0, -3).Format("2006-01-02")
+	if posted := result.DatePosted.Time.Format("2006-01-02"); posted != expectedPosted {
+		t.Errorf("Expected date posted %s, got %s", expectedPosted, posted)
+	}
+	if result.Status != models.SUBMITTED {
+		t.Errorf("Expected status to be SUBMITTED, got %s", result.Status)
+	}
+
+	p, err := Detect(string(testData))
+	if err != nil || p.Name() != "workday" {
+		t.Errorf("Expected workday parser to be detected, got %v, %v", p, err)
+	}
+}
+
+func TestParseWorkdayJobLocationsSummary(t *testing.T) {
+	testHTML := `
+	<h2 data-automation-id="jobPostingHeader">Data Analyst</h2>
+	<div data-automation-id="locations"><dl><dt>locations</dt><dd>2 Locations</dd></dl></div>
+	<div data-automation-id="postedOn"><dl><dt>posted on</dt><dd>Posted Today</dd></dl></div>
+	'mf-URL: https://acme.wd1.myworkdayjobs.com/en-US/External/job/Remote/Data-Analyst_R-00123
+	`
+
+	result, err := ParseWorkdayJob(strings.Split(testHTML, "\n"), &models.JobApplication{})
+	if err != nil {
+		t.Fatalf("ParseWorkdayJob failed: %v", err)
+	}
+
+	if result.Location != "2 Locations" {
+		t.Errorf("Expected location '2 Locations', got '%s'", result.Location)
+	}
+	if result.Company != "acme" {
+		t.Errorf("Expected company 'acme', got '%s'", result.Company)
+	}
+	today := time.Now().Format("2006-01-02")
+	if posted := result.DatePosted.Time.Format("2006-01-02"); posted != today {
+		t.Errorf("Expected date posted %s, got %s", today, posted)
+	}
+}
+
+func TestParseWorkdayPostedOn(t *testing.T) {
+	now := time.Date(2024, 3, 15, 18, 30, 0, 0, time.UTC)
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"Posted Today", "2024-03-15", true},
+		{"Posted Yesterday", "2024-03-14", true},
+		{"Posted 5 Days Ago", "2024-03-10", true},
+		{"Posted 30+ Days Ago", "2024-02-14", true},
+		{"Recently", "", false},
+	}
+
+	for _, test := range tests {
+		got, ok := parseWorkdayPostedOn(test.input, now)
+		if ok != test.ok {
+			t.Errorf("parseWorkdayPostedOn('%s') ok = %v, expected %v", test.input, ok, test.ok)
+			continue
+		}
+		if ok && got.Format("2006-01-02") != test.expected {
+			t.Errorf("parseWorkdayPostedOn('%s') = %s, expected %s", test.input, got.Format("2006-01-02"), test.expected)
+		}
+	}
+}
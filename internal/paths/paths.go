@@ -0,0 +1,193 @@
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"track-my-job-apps/internal/profile"
+)
+
+// AppName names the app's folders under the per-user directories
+const AppName = "track-my-job-apps"
+
+// EnvDir overrides both the data and config directories, like the -data-dir flag
+const EnvDir = "TRACK_MY_JOB_APPS_DIR"
+
+const (
+	databaseFile    = "job_apps.db"
+	configFile      = "config.json"
+	credentialsFile = "credentials.json"
+	tokenFile       = "token.json"
+	profilesFile    = "profiles.json"
+)
+
+// Dirs are the folders the app keeps its files in. Data holds the database;
+// Config holds the settings and the Google Drive credentials.
+type Dirs struct {
+	Data   string
+	Config string
+}
+
+// Resolve returns the app's folders. An override, from the -data-dir flag,
+// wins over the TRACK_MY_JOB_APPS_DIR environment variable, and either puts
+// every file in that one folder. Otherwise the data goes under XDG_DATA_HOME
+// (~/.local/share) and the config under XDG_CONFIG_HOME (~/.config) on
+// Linux, and both under the user config directory elsewhere.
+func Resolve(override string) (*Dirs, error) {
+	if override == "" {
+		override = os.Getenv(EnvDir)
+	}
+	if override != "" {
+		dir, err := filepath.Abs(override)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve data directory: %v", err)
+		}
+		return &Dirs{Data: dir, Config: dir}, nil
+	}
+
+	if runtime.GOOS != "linux" {
+		base, err := os.UserConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve data directory: %v", err)
+		}
+		dir := filepath.Join(base, AppName)
+		return &Dirs{Data: dir, Config: dir}, nil
+	}
+
+	dataHome, err := xdgDir("XDG_DATA_HOME", ".local", "share")
+	if err != nil {
+		return nil, err
+	}
+	configHome, err := xdgDir("XDG_CONFIG_HOME", ".config")
+	if err != nil {
+		return nil, err
+	}
+	return &Dirs{Data: filepath.Join(dataHome, AppName), Config: filepath.Join(configHome, AppName)}, nil
+}
+
+// xdgDir returns the directory in the XDG environment variable, or the
+// fallback under the home directory. Relative values are ignored, as the
+// XDG spec requires.
+func xdgDir(env string, fallback ...string) (string, error) {
+	if dir := os.Getenv(env); filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve data directory: %v", err)
+	}
+	return filepath.Join(append([]string{home}, fallback...)...), nil
+}
+
+// Database is the SQLite database file
+func (d *Dirs) Database() string {
+	return filepath.Join(d.Data, databaseFile)
+}
+
+// ProfileDatabase is the database of a profile. The default profile uses
+// Database; the others each get a folder under profiles.
+func (d *Dirs) ProfileDatabase(name string) string {
+	if strings.EqualFold(name, profile.Default) {
+		return d.Database()
+	}
+	return filepath.Join(d.Data, "profiles", name, databaseFile)
+}
+
+// Profiles is the file listing the profiles and the one in use
+func (d *Dirs) Profiles() string {
+	return filepath.Join(d.Config, profilesFile)
+}
+
+// ConfigFile is the settings file
+func (d *Dirs) ConfigFile() string {
+	return filepath.Join(d.Config, configFile)
+}
+
+// Credentials is the Google OAuth client file used for backups
+func (d *Dirs) Credentials() string {
+	return filepath.Join(d.Config, credentialsFile)
+}
+
+// Token is the cached Google OAuth token used for backups
+func (d *Dirs) Token() string {
+	return filepath.Join(d.Config, tokenFile)
+}
+
+// Create makes the data and config folders, readable only by the user
+func (d *Dirs) Create() error {
+	for _, dir := range []string{d.Data, d.Config} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("unable to create data directory: %v", err)
+		}
+	}
+	return nil
+}
+
+// MigrateLegacy moves the files older releases kept in legacyDir, usually
+// the working directory, into d. A file is only moved when d does not have
+// it yet, so it runs once. It returns the files moved.
+func (d *Dirs) MigrateLegacy(legacyDir string) ([]string, error) {
+	var moved []string
+	for name, target := range map[string]string{
+		databaseFile:    d.Database(),
+		configFile:      d.ConfigFile(),
+		credentialsFile: d.Credentials(),
+		tokenFile:       d.Token(),
+	} {
+		source, err := filepath.Abs(filepath.Join(legacyDir, name))
+		if err != nil {
+			return moved, fmt.Errorf("unable to migrate %s: %v", name, err)
+		}
+		if source == target || !exists(source) || exists(target) {
+			continue
+		}
+		if err := move(source, target); err != nil {
+			return moved, fmt.Errorf("unable to migrate %s: %v", name, err)
+		}
+		log.Printf("Moved %s to %s", source, target)
+		moved = append(moved, target)
+	}
+	return moved, nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// move renames source to target, copying it when they are on different
+// file systems
+func move(source string, target string) error {
+	if err := os.Rename(source, target); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Join(err, os.Remove(target))
+	}
+	in.Close()
+	return os.Remove(source)
+}
@@ -0,0 +1,98 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "/xdg/data")
+	t.Setenv("XDG_CONFIG_HOME", "relative/config")
+	t.Setenv(EnvDir, "")
+
+	if runtime.GOOS == "linux" {
+		dirs, err := Resolve("")
+		if err != nil {
+			t.Fatalf("Resolve failed: %v", err)
+		}
+		// A relative XDG directory is ignored
+		expected := Dirs{Data: "/xdg/data/" + AppName, Config: filepath.Join(home, ".config", AppName)}
+		if *dirs != expected {
+			t.Errorf("Expected %+v, got %+v", expected, *dirs)
+		}
+		if dirs.Database() != "/xdg/data/"+AppName+"/job_apps.db" {
+			t.Errorf("Unexpected database path %s", dirs.Database())
+		}
+	}
+
+	t.Setenv(EnvDir, "/from/env")
+	for _, test := range []struct {
+		override string
+		expected string
+	}{
+		{"", "/from/env"},
+		{"/from/flag", "/from/flag"},
+	} {
+		dirs, err := Resolve(test.override)
+		if err != nil {
+			t.Fatalf("Resolve(%q) failed: %v", test.override, err)
+		}
+		if dirs.Data != test.expected || dirs.Config != test.expected {
+			t.Errorf("Resolve(%q) = %+v, expected both in %s", test.override, *dirs, test.expected)
+		}
+		if got := dirs.ProfileDatabase("Default"); got != filepath.Join(test.expected, "job_apps.db") {
+			t.Errorf("Expected the default profile to use %s, got %s", dirs.Database(), got)
+		}
+		if got := dirs.ProfileDatabase("2024"); got != filepath.Join(test.expected, "profiles", "2024", "job_apps.db") {
+			t.Errorf("Unexpected profile database %s", got)
+		}
+	}
+}
+
+func TestMigrateLegacy(t *testing.T) {
+	legacy := t.TempDir()
+	dirs := &Dirs{Data: filepath.Join(t.TempDir(), "data"), Config: filepath.Join(t.TempDir(), "config")}
+	if err := dirs.Create(); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	for name, content := range map[string]string{"job_apps.db": "legacy db", "token.json": "legacy token", "config.json": "legacy config"} {
+		if err := os.WriteFile(filepath.Join(legacy, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A config already in place is kept
+	if err := os.WriteFile(dirs.ConfigFile(), []byte("current config"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := dirs.MigrateLegacy(legacy)
+	if err != nil {
+		t.Fatalf("MigrateLegacy failed: %v", err)
+	}
+	if len(moved) != 2 {
+		t.Errorf("Expected the database and token to move, got %v", moved)
+	}
+
+	for path, expected := range map[string]string{dirs.Database(): "legacy db", dirs.Token(): "legacy token", dirs.ConfigFile(): "current config"} {
+		data, err := os.ReadFile(path)
+		if err != nil || string(data) != expected {
+			t.Errorf("Expected %s to hold %q, got %q (%v)", path, expected, data, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(legacy, "job_apps.db")); !os.IsNotExist(err) {
+		t.Errorf("Expected the legacy database to be moved, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(legacy, "config.json")); err != nil {
+		t.Errorf("Expected the legacy config to be left alone, got %v", err)
+	}
+
+	// Running again moves nothing
+	if moved, err := dirs.MigrateLegacy(legacy); err != nil || len(moved) != 0 {
+		t.Errorf("Expected nothing to move on the second run, got %v (%v)", moved, err)
+	}
+}
@@ -0,0 +1,177 @@
+// Package pipeline defines the application status stages and which moves
+// between them are allowed.
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"track-my-job-apps/internal/config"
+	"track-my-job-apps/internal/models"
+)
+
+// Stage is one status in the pipeline
+type Stage struct {
+	Name  models.Status `json:"name"`
+	Label string        `json:"label"`
+	// Terminal stages end an application; they can only be left through Next
+	Terminal bool `json:"terminal"`
+	// Next lists the stages that can follow this one. Active stages without
+	// a list can move to any stage except SAVED.
+	Next []models.Status `json:"next"`
+}
+
+// Pipeline is the ordered list of stages
+type Pipeline struct {
+	stages []Stage
+	index  map[models.Status]int
+}
+
+// activeAfterGhosted are the stages a ghosted application returns to when the company reappears
+var activeAfterGhosted = []models.Status{models.PHONE_SCREEN, models.TAKE_HOME, models.REMOTE_INTERVIEW, models.ON_SITE_INTERVIEW, models.OFFER, models.REJECTED}
+
+// defaultStages is the built-in pipeline in display order
+var defaultStages = []Stage{
+	{Name: models.SAVED, Label: "Saved (not yet applied)", Next: []models.Status{models.SUBMITTED, models.WITHDRAWN}},
+	{Name: models.SUBMITTED, Label: "Submitted"},
+	{Name: models.PHONE_SCREEN, Label: "Phone screen"},
+	{Name: models.TAKE_HOME, Label: "Take-home"},
+	{Name: models.REMOTE_INTERVIEW, Label: "Remote interview"},
+	{Name: models.ON_SITE_INTERVIEW, Label: "On-site interview"},
+	{Name: models.OFFER, Label: "Offer", Next: []models.Status{models.ACCEPTED, models.DECLINED, models.WITHDRAWN, models.REJECTED}},
+	{Name: models.ACCEPTED, Label: "Accepted", Terminal: true},
+	{Name: models.DECLINED, Label: "Declined", Terminal: true},
+	{Name: models.REJECTED, Label: "Rejected", Terminal: true},
+	{Name: models.WITHDRAWN, Label: "Withdrawn", Terminal: true},
+	{Name: models.GHOSTED, Label: "Ghosted", Terminal: true, Next: activeAfterGhosted},
+}
+
+// Default returns the built-in pipeline
+func Default() *Pipeline {
+	p, _ := New(nil)
+	return p
+}
+
+// New builds the pipeline from the built-in stages and the custom stages in
+// the config. A custom stage with a built-in name replaces that stage.
+func New(cfg *config.Config) (*Pipeline, error) {
+	p := &Pipeline{stages: append([]Stage(nil), defaultStages...)}
+	p.reindex()
+	if cfg == nil {
+		return p, nil
+	}
+
+	for _, custom := range cfg.Statuses {
+		stage := Stage{
+			Name:     normalize(custom.Name),
+			Label:    custom.Label,
+			Terminal: custom.Terminal,
+		}
+		if stage.Label == "" {
+			stage.Label = string(stage.Name)
+		}
+		for _, next := range custom.Next {
+			stage.Next = append(stage.Next, normalize(next))
+		}
+
+		if i, ok := p.index[stage.Name]; ok {
+			p.stages = append(p.stages[:i], p.stages[i+1:]...)
+			p.reindex()
+		}
+		position := len(p.stages)
+		if custom.After != "" {
+			i, ok := p.index[normalize(custom.After)]
+			if !ok {
+				return nil, fmt.Errorf("status %s is listed after unknown status %s", stage.Name, custom.After)
+			}
+			position = i + 1
+		}
+		p.stages = append(p.stages[:position], append([]Stage{stage}, p.stages[position:]...)...)
+		p.reindex()
+	}
+
+	for _, stage := range p.stages {
+		for _, next := range stage.Next {
+			if _, ok := p.index[next]; !ok {
+				return nil, fmt.Errorf("status %s can be followed by unknown status %s", stage.Name, next)
+			}
+		}
+	}
+	return p, nil
+}
+
+// Stages returns the stages in display order
+func (p *Pipeline) Stages() []Stage {
+	stages := make([]Stage, len(p.stages))
+	for i, stage := range p.stages {
+		stages[i] = stage
+		stages[i].Next = p.NextStages(stage.Name)
+	}
+	return stages
+}
+
+// Lookup returns the stage for a status
+func (p *Pipeline) Lookup(status models.Status) (Stage, bool) {
+	i, ok := p.index[status]
+	if !ok {
+		return Stage{}, false
+	}
+	return p.stages[i], true
+}
+
+// IsTerminal reports whether a status ends an application
+func (p *Pipeline) IsTerminal(status models.Status) bool {
+	stage, ok := p.Lookup(status)
+	return ok && stage.Terminal
+}
+
+// NextStages lists the statuses an application can move to from status
+func (p *Pipeline) NextStages(status models.Status) []models.Status {
+	stage, ok := p.Lookup(status)
+	if !ok {
+		// Applications with a status removed from the config may move anywhere
+		stage = Stage{Name: status}
+	}
+	if len(stage.Next) > 0 || stage.Terminal {
+		return append([]models.Status(nil), stage.Next...)
+	}
+
+	var next []models.Status
+	for _, candidate := range p.stages {
+		if candidate.Name != status && candidate.Name != models.SAVED {
+			next = append(next, candidate.Name)
+		}
+	}
+	return next
+}
+
+// Validate returns an error when an application cannot move from one status to another
+func (p *Pipeline) Validate(from models.Status, to models.Status) error {
+	if _, ok := p.Lookup(to); !ok {
+		return fmt.Errorf("unknown status %s", to)
+	}
+	if from == "" || from == to {
+		return nil
+	}
+	for _, next := range p.NextStages(from) {
+		if next == to {
+			return nil
+		}
+	}
+	if p.IsTerminal(from) {
+		return fmt.Errorf("cannot move from %s to %s: %s is a final status", from, to, from)
+	}
+	return fmt.Errorf("cannot move from %s to %s", from, to)
+}
+
+func (p *Pipeline) reindex() {
+	p.index = map[models.Status]int{}
+	for i, stage := range p.stages {
+		p.index[stage.Name] = i
+	}
+}
+
+// normalize turns "Recruiter chat" into RECRUITER_CHAT
+func normalize(name string) models.Status {
+	return models.Status(strings.ToUpper(strings.Join(strings.Fields(strings.ReplaceAll(name, "-", " ")), "_")))
+}
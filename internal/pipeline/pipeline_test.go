@@ -0,0 +1,77 @@
+package pipeline
+
+import (
+	"testing"
+
+	"track-my-job-apps/internal/config"
+	"track-my-job-apps/internal/models"
+)
+
+func TestValidate(t *testing.T) {
+	p := Default()
+
+	tests := []struct {
+		from    models.Status
+		to      models.Status
+		allowed bool
+	}{
+		{"", models.SAVED, true},
+		{"", "BOGUS", false},
+		{models.SAVED, models.SUBMITTED, true},
+		{models.SAVED, models.OFFER, false},
+		{models.SUBMITTED, models.TAKE_HOME, true},
+		{models.SUBMITTED, models.GHOSTED, true},
+		{models.PHONE_SCREEN, models.SAVED, false},
+		{models.OFFER, models.ACCEPTED, true},
+		{models.OFFER, models.PHONE_SCREEN, false},
+		{models.REJECTED, models.PHONE_SCREEN, false},
+		{models.ACCEPTED, models.WITHDRAWN, false},
+		{models.GHOSTED, models.PHONE_SCREEN, true},
+		{models.REJECTED, models.REJECTED, true},
+	}
+	for _, test := range tests {
+		err := p.Validate(test.from, test.to)
+		if allowed := err == nil; allowed != test.allowed {
+			t.Errorf("Validate(%s, %s) = %v, expected allowed %v", test.from, test.to, err, test.allowed)
+		}
+	}
+
+	if !p.IsTerminal(models.DECLINED) || p.IsTerminal(models.OFFER) {
+		t.Errorf("Expected DECLINED to be terminal and OFFER to be active")
+	}
+}
+
+func TestNewWithCustomStages(t *testing.T) {
+	cfg := config.Default()
+	cfg.Statuses = []config.Stage{
+		{Name: "Recruiter chat", Label: "Recruiter chat", After: "SUBMITTED"},
+		{Name: "ON_HOLD", Terminal: true, Next: []string{"phone screen", "rejected"}},
+	}
+
+	p, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	stages := p.Stages()
+	if stages[2].Name != "RECRUITER_CHAT" || stages[2].Label != "Recruiter chat" {
+		t.Errorf("Expected RECRUITER_CHAT after SUBMITTED, got %+v", stages[2])
+	}
+	if last := stages[len(stages)-1]; last.Name != "ON_HOLD" || !last.Terminal || last.Label != "ON_HOLD" {
+		t.Errorf("Expected ON_HOLD last, got %+v", last)
+	}
+	if err := p.Validate(models.SUBMITTED, "RECRUITER_CHAT"); err != nil {
+		t.Errorf("Expected active stages to reach custom stages: %v", err)
+	}
+	if err := p.Validate("ON_HOLD", models.PHONE_SCREEN); err != nil {
+		t.Errorf("Expected ON_HOLD to move to its next stages: %v", err)
+	}
+	if err := p.Validate("ON_HOLD", models.OFFER); err == nil {
+		t.Errorf("Expected ON_HOLD not to move to OFFER")
+	}
+
+	cfg.Statuses = []config.Stage{{Name: "LATE", After: "NOWHERE"}}
+	if _, err := New(cfg); err == nil {
+		t.Errorf("Expected an error for a stage after an unknown status")
+	}
+}
@@ -0,0 +1,150 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Default is the profile every install starts with. It keeps the database
+// and Drive backup older releases used.
+const Default = "default"
+
+// DefaultBackupName is the Drive file the default profile backs up to
+const DefaultBackupName = "job_apps_backup.db"
+
+// validName keeps profile names usable as folder and Drive file names
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 _-]{0,49}$`)
+
+// Profile is a separate job search with its own database and backup
+type Profile struct {
+	Name string `json:"name"`
+	// BackupName is the Google Drive file the database is backed up to
+	BackupName string `json:"backupName"`
+}
+
+// Profiles is the list of profiles and the one in use, saved between launches
+type Profiles struct {
+	Active   string    `json:"active"`
+	Profiles []Profile `json:"profiles"`
+
+	path string
+}
+
+// Load reads the profiles saved at path. A missing file gives just the
+// default profile.
+func Load(path string) (*Profiles, error) {
+	profiles := &Profiles{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("unable to read profiles file: %v", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, profiles); err != nil {
+			return nil, fmt.Errorf("unable to parse profiles file: %v", err)
+		}
+	}
+
+	if profiles.Get(Default) == nil {
+		profiles.Profiles = append([]Profile{{Name: Default, BackupName: DefaultBackupName}}, profiles.Profiles...)
+	}
+	if profiles.Get(profiles.Active) == nil {
+		profiles.Active = Default
+	}
+	for i := range profiles.Profiles {
+		if !validName.MatchString(profiles.Profiles[i].Name) {
+			return nil, fmt.Errorf("unable to parse profiles file: invalid profile name %q", profiles.Profiles[i].Name)
+		}
+		if profiles.Profiles[i].BackupName == "" {
+			profiles.Profiles[i].BackupName = defaultBackupName(profiles.Profiles[i].Name)
+		}
+	}
+	return profiles, nil
+}
+
+// defaultBackupName is the Drive file a profile backs up to unless set
+func defaultBackupName(name string) string {
+	if strings.EqualFold(name, Default) {
+		return DefaultBackupName
+	}
+	return "job_apps_" + strings.ReplaceAll(strings.ToLower(name), " ", "_") + "_backup.db"
+}
+
+// Save writes the profiles back to the file they were loaded from
+func (p *Profiles) Save() error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to save profiles: %v", err)
+	}
+	if err := os.WriteFile(p.path, data, 0600); err != nil {
+		return fmt.Errorf("unable to save profiles: %v", err)
+	}
+	return nil
+}
+
+// Get returns the profile named name, ignoring case, or nil
+func (p *Profiles) Get(name string) *Profile {
+	for i := range p.Profiles {
+		if strings.EqualFold(p.Profiles[i].Name, name) {
+			return &p.Profiles[i]
+		}
+	}
+	return nil
+}
+
+// Put adds a profile, or changes the backup of the one with the same name.
+// An empty backup name becomes one derived from the profile name.
+func (p *Profiles) Put(profile Profile) (*Profile, error) {
+	profile.Name = strings.TrimSpace(profile.Name)
+	if !validName.MatchString(profile.Name) {
+		return nil, fmt.Errorf("invalid profile name %q: use up to 50 letters, digits, spaces, dashes and underscores", profile.Name)
+	}
+	profile.BackupName = strings.TrimSpace(profile.BackupName)
+	if strings.ContainsAny(profile.BackupName, `'"\/`) {
+		return nil, fmt.Errorf("invalid backup name %q", profile.BackupName)
+	}
+
+	existing := p.Get(profile.Name)
+	if profile.BackupName == "" {
+		if existing != nil {
+			profile.BackupName = existing.BackupName
+		} else {
+			profile.BackupName = defaultBackupName(profile.Name)
+		}
+	}
+	// Two profiles backing up to one file would overwrite each other
+	for i := range p.Profiles {
+		if &p.Profiles[i] != existing && strings.EqualFold(p.Profiles[i].BackupName, profile.BackupName) {
+			return nil, fmt.Errorf("backup %q is already used by profile %q", profile.BackupName, p.Profiles[i].Name)
+		}
+	}
+
+	if existing != nil {
+		existing.BackupName = profile.BackupName
+		return existing, nil
+	}
+	p.Profiles = append(p.Profiles, profile)
+	return &p.Profiles[len(p.Profiles)-1], nil
+}
+
+// Remove deletes a profile from the list, leaving its database on disk. The
+// active and default profiles cannot be removed.
+func (p *Profiles) Remove(name string) error {
+	if strings.EqualFold(name, Default) {
+		return fmt.Errorf("cannot remove the default profile")
+	}
+	if strings.EqualFold(name, p.Active) {
+		return fmt.Errorf("cannot remove the profile in use; switch to another first")
+	}
+	for i := range p.Profiles {
+		if strings.EqualFold(p.Profiles[i].Name, name) {
+			p.Profiles = append(p.Profiles[:i], p.Profiles[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no profile named %q", name)
+}
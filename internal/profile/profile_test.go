@@ -0,0 +1,110 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMissingFile(t *testing.T) {
+	profiles, err := Load(filepath.Join(t.TempDir(), "profiles.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if profiles.Active != Default || len(profiles.Profiles) != 1 || profiles.Profiles[0].BackupName != DefaultBackupName {
+		t.Errorf("Expected only the default profile, got %+v", profiles)
+	}
+}
+
+func TestLoadFillsBackupNames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	if err := os.WriteFile(path, []byte(`{"active":"2024 Search","profiles":[{"name":"2024 Search"}]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	profiles, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if profiles.Active != "2024 Search" || profiles.Get("2024 search").BackupName != "job_apps_2024_search_backup.db" {
+		t.Errorf("Expected a derived backup name, got %+v", profiles)
+	}
+	if profiles.Get(Default) == nil {
+		t.Errorf("Expected the default profile to be added")
+	}
+	if err := os.WriteFile(path, []byte(`{"profiles":[{"name":"../outside"}]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Errorf("Expected an error loading a profile name that is not a folder name")
+	}
+}
+
+func TestPut(t *testing.T) {
+	profiles, _ := Load(filepath.Join(t.TempDir(), "profiles.json"))
+
+	tests := []struct {
+		profile  Profile
+		expected string
+		valid    bool
+	}{
+		{Profile{Name: " Search 2024 "}, "job_apps_search_2024_backup.db", true},
+		{Profile{Name: "partner", BackupName: "partner_jobs.db"}, "partner_jobs.db", true},
+		// Updating keeps the existing name and changes the backup
+		{Profile{Name: "SEARCH 2024", BackupName: "archive.db"}, "archive.db", true},
+		{Profile{Name: ""}, "", false},
+		{Profile{Name: "../escape"}, "", false},
+		{Profile{Name: "quotes", BackupName: "it's.db"}, "", false},
+		// Backup files cannot be shared between profiles
+		{Profile{Name: "copy", BackupName: "Partner_Jobs.db"}, "", false},
+		{Profile{Name: "partner", BackupName: "job_apps_backup.db"}, "", false},
+		{Profile{Name: "Partner", BackupName: "PARTNER_JOBS.db"}, "PARTNER_JOBS.db", true},
+	}
+	for _, test := range tests {
+		saved, err := profiles.Put(test.profile)
+		if !test.valid {
+			if err == nil {
+				t.Errorf("Put(%+v): expected an error", test.profile)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Put(%+v) failed: %v", test.profile, err)
+			continue
+		}
+		if saved.BackupName != test.expected {
+			t.Errorf("Put(%+v): expected backup %s, got %s", test.profile, test.expected, saved.BackupName)
+		}
+	}
+	if len(profiles.Profiles) != 3 || profiles.Get("search 2024").Name != "Search 2024" {
+		t.Errorf("Expected default, Search 2024 and partner, got %+v", profiles.Profiles)
+	}
+}
+
+func TestSaveAndRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	profiles, _ := Load(path)
+	profiles.Put(Profile{Name: "2024"})
+	profiles.Put(Profile{Name: "current"})
+	profiles.Active = "current"
+	if err := profiles.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// The active profile is remembered
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Active != "current" || len(loaded.Profiles) != 3 {
+		t.Errorf("Expected 3 profiles with current active, got %+v", loaded)
+	}
+
+	for _, name := range []string{Default, "Current", "missing"} {
+		if err := loaded.Remove(name); err == nil {
+			t.Errorf("Remove(%q): expected an error", name)
+		}
+	}
+	if err := loaded.Remove("2024"); err != nil || loaded.Get("2024") != nil {
+		t.Errorf("Expected 2024 to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package salary
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"track-my-job-apps/internal/config"
+	"track-my-job-apps/internal/models"
+)
+
+// Annualize converts an application's structured salary into an annual base
+// in the home currency. Ranges are annualized at their midpoint, and a lone
+// minimum ("from $120k") or maximum ("up to $150k") is used as is.
+func Annualize(app *models.JobApplication, cfg *config.Config) (float64, error) {
+	if app.SalaryMin == 0 && app.SalaryMax == 0 {
+		return 0, fmt.Errorf("no salary parsed")
+	}
+
+	base := (app.SalaryMin + app.SalaryMax) / 2
+	if app.SalaryMax == 0 {
+		base = app.SalaryMin
+	} else if app.SalaryMin == 0 {
+		base = app.SalaryMax
+	}
+
+	switch app.SalaryPeriod {
+	case models.HOURLY:
+		base *= cfg.HoursPerYear
+	case models.DAILY:
+		base *= cfg.WorkDaysPerYear
+	case models.WEEKLY:
+		base *= 52
+	case models.MONTHLY:
+		base *= 12
+	case models.ANNUAL:
+	default:
+		return 0, fmt.Errorf("unknown pay period %q", app.SalaryPeriod)
+	}
+
+	currency := app.SalaryCurrency
+	if currency == "" {
+		currency = cfg.HomeCurrency
+	}
+	rate, ok := cfg.ExchangeRates[currency]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("no exchange rate configured for %s", currency)
+	}
+
+	return base * rate, nil
+}
+
+// AnnualizeAll sets AnnualizedSalary on every application, leaving it zero
+// when the salary is missing or cannot be converted
+func AnnualizeAll(apps []models.JobApplication, cfg *config.Config) {
+	for i := range apps {
+		annualized, err := Annualize(&apps[i], cfg)
+		if err != nil {
+			continue
+		}
+		apps[i].AnnualizedSalary = annualized
+		apps[i].AnnualizedCurrency = cfg.HomeCurrency
+	}
+}
+
+// AnnualSQL returns a SQL expression over the apps columns that computes the
+// same annual base as Annualize, so queries can filter and sort by pay. It is
+// NULL when the salary is missing or cannot be converted.
+func AnnualSQL(cfg *config.Config) string {
+	// The home currency always converts to itself
+	rates := []string{fmt.Sprintf("WHEN %s THEN 1", sqlString(cfg.HomeCurrency))}
+	currencies := make([]string, 0, len(cfg.ExchangeRates))
+	for currency := range cfg.ExchangeRates {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		if rate := cfg.ExchangeRates[currency]; rate > 0 {
+			rates = append(rates, fmt.Sprintf("WHEN %s THEN %s", sqlString(currency), sqlNumber(rate)))
+		}
+	}
+
+	return fmt.Sprintf(`(CASE WHEN COALESCE(salary_min, 0) = 0 AND COALESCE(salary_max, 0) = 0 THEN NULL
+		ELSE (CASE WHEN COALESCE(salary_max, 0) = 0 THEN salary_min WHEN COALESCE(salary_min, 0) = 0 THEN salary_max
+			ELSE (salary_min + salary_max) / 2 END)
+		* (CASE salary_period WHEN '%s' THEN %s WHEN '%s' THEN %s WHEN '%s' THEN 52 WHEN '%s' THEN 12 WHEN '%s' THEN 1 END)
+		* (CASE COALESCE(NULLIF(salary_currency, ''), %s) %s END) END)`,
+		models.HOURLY, sqlNumber(cfg.HoursPerYear), models.DAILY, sqlNumber(cfg.WorkDaysPerYear),
+		models.WEEKLY, models.MONTHLY, models.ANNUAL,
+		sqlString(cfg.HomeCurrency), strings.Join(rates, " "))
+}
+
+// sqlString quotes s as a SQL string literal
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func sqlNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,111 @@
+package salary
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	"track-my-job-apps/internal/config"
+	"track-my-job-apps/internal/models"
+)
+
+func TestAnnualize(t *testing.T) {
+	cfg := config.Default()
+	cfg.ExchangeRates["EUR"] = 1.1
+
+	tests := []struct {
+		name     string
+		app      models.JobApplication
+		expected float64
+	}{
+		{"annual range midpoint", models.JobApplication{SalaryMin: 100000, SalaryMax: 140000, SalaryCurrency: "USD", SalaryPeriod: models.ANNUAL}, 120000},
+		{"maximum only", models.JobApplication{SalaryMax: 150000, SalaryCurrency: "USD", SalaryPeriod: models.ANNUAL}, 150000},
+		{"hourly", models.JobApplication{SalaryMin: 50, SalaryMax: 50, SalaryCurrency: "USD", SalaryPeriod: models.HOURLY}, 104000},
+		{"monthly", models.JobApplication{SalaryMin: 5000, SalaryMax: 5000, SalaryCurrency: "USD", SalaryPeriod: models.MONTHLY}, 60000},
+		{"converted currency", models.JobApplication{SalaryMin: 60000, SalaryMax: 60000, SalaryCurrency: "EUR", SalaryPeriod: models.ANNUAL}, 66000},
+	}
+
+	for _, test := range tests {
+		got, err := Annualize(&test.app, cfg)
+		if err != nil {
+			t.Errorf("%s: Annualize failed: %v", test.name, err)
+			continue
+		}
+		if got < test.expected-0.01 || got > test.expected+0.01 {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, got)
+		}
+	}
+}
+
+func TestAnnualizeErrors(t *testing.T) {
+	cfg := config.Default()
+
+	if _, err := Annualize(&models.JobApplication{}, cfg); err == nil {
+		t.Errorf("Expected error for application without salary")
+	}
+	gbp := &models.JobApplication{SalaryMin: 50000, SalaryMax: 60000, SalaryCurrency: "GBP", SalaryPeriod: models.ANNUAL}
+	if _, err := Annualize(gbp, cfg); err == nil {
+		t.Errorf("Expected error for currency without exchange rate")
+	}
+}
+
+func TestAnnualizeAll(t *testing.T) {
+	cfg := config.Default()
+	apps := []models.JobApplication{
+		{Company: "NoSalary"},
+		{Company: "Hourly", SalaryMin: 80, SalaryMax: 80, SalaryCurrency: "USD", SalaryPeriod: models.HOURLY},
+	}
+
+	AnnualizeAll(apps, cfg)
+
+	if apps[1].AnnualizedSalary != 80*cfg.HoursPerYear || apps[1].AnnualizedCurrency != "USD" {
+		t.Errorf("Expected the hourly salary to be annualized in USD, got %v %s", apps[1].AnnualizedSalary, apps[1].AnnualizedCurrency)
+	}
+	if apps[0].AnnualizedSalary != 0 || apps[0].AnnualizedCurrency != "" {
+		t.Errorf("Expected no annualized salary without a salary, got %v '%s'", apps[0].AnnualizedSalary, apps[0].AnnualizedCurrency)
+	}
+}
+
+func TestAnnualSQL(t *testing.T) {
+	cfg := config.Default()
+	cfg.ExchangeRates["EUR"] = 1.1
+	cfg.ExchangeRates["O'K"] = 2
+
+	conn, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+
+	// The SQL expression agrees with Annualize, including when it fails
+	apps := []models.JobApplication{
+		{SalaryMin: 100000, SalaryMax: 140000, SalaryCurrency: "USD", SalaryPeriod: models.ANNUAL},
+		{SalaryMin: 50, SalaryPeriod: models.HOURLY},
+		{SalaryMax: 150000, SalaryPeriod: models.ANNUAL},
+		{SalaryMin: 400, SalaryMax: 500, SalaryCurrency: "EUR", SalaryPeriod: models.DAILY},
+		{SalaryMin: 1000, SalaryCurrency: "O'K", SalaryPeriod: models.WEEKLY},
+		{SalaryMin: 50000, SalaryCurrency: "GBP", SalaryPeriod: models.ANNUAL},
+		{SalaryMin: 5000, SalaryPeriod: "FORTNIGHTLY"},
+		{},
+	}
+	query := "SELECT " + AnnualSQL(cfg) + " FROM (SELECT ? AS salary_min, ? AS salary_max, ? AS salary_currency, ? AS salary_period)"
+	for _, app := range apps {
+		var got sql.NullFloat64
+		err := conn.QueryRow(query, app.SalaryMin, app.SalaryMax, app.SalaryCurrency, string(app.SalaryPeriod)).Scan(&got)
+		if err != nil {
+			t.Fatalf("Failed to evaluate AnnualSQL: %v", err)
+		}
+
+		expected, err := Annualize(&app, cfg)
+		if err != nil {
+			if got.Valid {
+				t.Errorf("%+v: expected NULL, got %v", app, got.Float64)
+			}
+			continue
+		}
+		if !got.Valid || got.Float64 < expected-0.01 || got.Float64 > expected+0.01 {
+			t.Errorf("%+v: expected %v, got %+v", app, expected, got)
+		}
+	}
+}
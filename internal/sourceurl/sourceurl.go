@@ -0,0 +1,124 @@
+// Package sourceurl normalizes job posting URLs and reads job board IDs from them.
+package sourceurl
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// trackingParams are query parameters job boards and referrers add for attribution
+var trackingParams = map[string]bool{
+	"gh_src":            true,
+	"source":            true,
+	"src":               true,
+	"ref":               true,
+	"refid":             true,
+	"trackingid":        true,
+	"trk":               true,
+	"lever-source":      true,
+	"lever-origin":      true,
+	"lever-source[]":    true,
+	"gclid":             true,
+	"fbclid":            true,
+	"li_fat_id":         true,
+	"lipi":              true,
+	"ebp":               true,
+	"originalsubdomain": true,
+}
+
+var (
+	linkedInViewPattern = regexp.MustCompile(`/jobs/view/(?:[^/]*-)?(\d+)`)
+	uuidPattern         = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	digitsPattern       = regexp.MustCompile(`^\d+$`)
+)
+
+// Canonical returns the URL without tracking parameters or fragment, so the
+// same posting shared from different places compares equal. LinkedIn search
+// and collection URLs are rewritten to the posting's /jobs/view/ page.
+func Canonical(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return strings.TrimSpace(raw)
+	}
+
+	host := strings.ToLower(u.Host)
+	if strings.HasSuffix(host, "linkedin.com") {
+		if id := JobID(raw); id != "" {
+			return "https://www.linkedin.com/jobs/view/" + id + "/"
+		}
+	}
+
+	query := u.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if trackingParams[lower] || strings.HasPrefix(lower, "utm_") {
+			query.Del(key)
+		}
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = host
+	u.RawQuery = query.Encode()
+	u.Fragment = ""
+	u.RawFragment = ""
+	if isHost(host, "lever.co") || isHost(host, "ashbyhq.com") {
+		// Application form URLs point at the same posting
+		u.Path = strings.TrimSuffix(u.Path, "/")
+		u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/apply"), "/application")
+	}
+	return u.String()
+}
+
+// JobID returns the job board's own ID for the posting: Greenhouse gh_jid or
+// /jobs/<id>, LinkedIn currentJobId or /jobs/view/<id>, the Lever and Ashby
+// posting UUID, or the Workday requisition suffix. It returns "" when the
+// URL carries no recognizable ID.
+func JobID(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	host := strings.ToLower(u.Hostname())
+	query := u.Query()
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	// gh_jid is also used by career sites that embed a Greenhouse board
+	if id := query.Get("gh_jid"); id != "" {
+		return id
+	}
+
+	switch {
+	case isHost(host, "greenhouse.io"):
+		if id := query.Get("token"); id != "" {
+			return id
+		}
+		for i, segment := range segments {
+			if segment == "jobs" && i+1 < len(segments) && digitsPattern.MatchString(segments[i+1]) {
+				return segments[i+1]
+			}
+		}
+	case isHost(host, "linkedin.com"):
+		if id := query.Get("currentJobId"); id != "" {
+			return id
+		}
+		if match := linkedInViewPattern.FindStringSubmatch(u.Path); len(match) > 1 {
+			return match[1]
+		}
+	case isHost(host, "lever.co"), isHost(host, "ashbyhq.com"):
+		if len(segments) > 1 && uuidPattern.MatchString(segments[1]) {
+			return strings.ToLower(segments[1])
+		}
+	case isHost(host, "myworkdayjobs.com"), isHost(host, "myworkdaysite.com"):
+		last := segments[len(segments)-1]
+		if i := strings.LastIndex(last, "_"); i != -1 && i+1 < len(last) {
+			return last[i+1:]
+		}
+	}
+	return ""
+}
+
+// isHost reports whether host is domain or one of its subdomains
+func isHost(host string, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
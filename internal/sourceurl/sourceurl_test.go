@@ -0,0 +1,48 @@
+package sourceurl
+
+import "testing"
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+	}{
+		{"https://job-boards.greenhouse.io/figma/jobs/5552540004?gh_jid=5552540004&gh_src=28109e334us&source=LinkedIn", "https://job-boards.greenhouse.io/figma/jobs/5552540004?gh_jid=5552540004"},
+		{"https://jobs.lever.co/plaid/8d3f1c62-4b2e-4f7a-9c55-2a1f3e7b9d10/apply?lever-source=LinkedIn", "https://jobs.lever.co/plaid/8d3f1c62-4b2e-4f7a-9c55-2a1f3e7b9d10"},
+		{"https://jobs.ashbyhq.com/ramp/3a1e2f4b-9c8d-4e7f-a6b5-c4d3e2f1a0b9?utm_source=linkedin&utm_medium=jobboard", "https://jobs.ashbyhq.com/ramp/3a1e2f4b-9c8d-4e7f-a6b5-c4d3e2f1a0b9"},
+		{"https://www.linkedin.com/jobs/collections/recommended/?currentJobId=4012345678&trk=flagship", "https://www.linkedin.com/jobs/view/4012345678/"},
+		{"https://WWW.Example.com/careers/42?ref=hn#apply", "https://www.example.com/careers/42"},
+		{"not a url", "not a url"},
+	}
+
+	for _, test := range tests {
+		if got := Canonical(test.raw); got != test.expected {
+			t.Errorf("Canonical(%q) = %q, expected %q", test.raw, got, test.expected)
+		}
+	}
+}
+
+func TestJobID(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+	}{
+		{"https://job-boards.greenhouse.io/checkr/jobs/7078460", "7078460"},
+		{"https://careers.example.com/open-roles?gh_jid=4455667", "4455667"},
+		{"https://boards.greenhouse.io/embed/job_app?for=acme&token=998877", "998877"},
+		{"https://www.linkedin.com/jobs/view/4012345678/", "4012345678"},
+		{"https://www.linkedin.com/jobs/view/senior-engineer-at-acme-4012345678", "4012345678"},
+		{"https://www.linkedin.com/jobs/search/?currentJobId=4011112222&keywords=go", "4011112222"},
+		{"https://jobs.lever.co/plaid/8D3F1C62-4B2E-4F7A-9C55-2A1F3E7B9D10/apply", "8d3f1c62-4b2e-4f7a-9c55-2a1f3e7b9d10"},
+		{"https://jobs.ashbyhq.com/ramp/3a1e2f4b-9c8d-4e7f-a6b5-c4d3e2f1a0b9", "3a1e2f4b-9c8d-4e7f-a6b5-c4d3e2f1a0b9"},
+		{"https://acme.wd1.myworkdayjobs.com/en-US/External/job/Remote/Data-Analyst_R-00123", "R-00123"},
+		{"https://careers.shopwise.example/jobs/eng-2291-staff-platform-engineer", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if got := JobID(test.raw); got != test.expected {
+			t.Errorf("JobID(%q) = %q, expected %q", test.raw, got, test.expected)
+		}
+	}
+}
@@ -2,10 +2,19 @@ package main
 
 import (
 	"embed"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+
+	"track-my-job-apps/internal/database"
+	"track-my-job-apps/internal/paths"
+	"track-my-job-apps/internal/profile"
 )
 
 //go:embed all:frontend/dist
@@ -13,11 +22,32 @@ var assets embed.FS
 
 // main
 func main() {
+	migrateDryRun := flag.Bool("migrate-dry-run", false, "test the pending database migrations without applying them, then exit")
+	rebuildSearchIndex := flag.Bool("rebuild-search-index", false, "reindex every application for full-text search, then exit")
+	profileName := flag.String("profile", "", "the profile -migrate-dry-run and -rebuild-search-index work on (default: the one used last)")
+	dataDir := flag.String("data-dir", "", "keep the database, config and credentials in this folder (default: per-user folders, or $"+paths.EnvDir+")")
+	flag.Parse()
+
+	dirs, err := openDirs(*dataDir)
+	if err != nil {
+		log.Fatalf("Failed to set up data directory: %v", err)
+	}
+	if *migrateDryRun || *rebuildSearchIndex {
+		path, err := profileDatabase(dirs, *profileName)
+		if err != nil {
+			log.Fatalf("Failed to find profile: %v", err)
+		}
+		if *migrateDryRun {
+			os.Exit(dryRunMigrations(path))
+		}
+		os.Exit(rebuildSearch(path))
+	}
+
 	// Create an instance of the app structure
-	app := NewApp()
+	app := NewApp(dirs)
 
 	// Create application with options
-	err := wails.Run(&options.App{
+	err = wails.Run(&options.App{
 		Title:  "Track My Job Apps",
 		Width:  1024,
 		Height: 768,
@@ -36,3 +66,90 @@ func main() {
 		println("Error:", err.Error())
 	}
 }
+
+// openDirs resolves and creates the app's folders, moving in the files older
+// releases kept in the working directory
+func openDirs(override string) (*paths.Dirs, error) {
+	dirs, err := paths.Resolve(override)
+	if err != nil {
+		return nil, err
+	}
+	if err := dirs.Create(); err != nil {
+		return nil, err
+	}
+	if _, err := dirs.MigrateLegacy("."); err != nil {
+		return nil, err
+	}
+	log.Printf("Using data folder %s and config folder %s", dirs.Data, dirs.Config)
+	return dirs, nil
+}
+
+// profileDatabase returns the database of the named profile, or of the one
+// used last when name is empty
+func profileDatabase(dirs *paths.Dirs, name string) (string, error) {
+	profiles, err := profile.Load(dirs.Profiles())
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		name = profiles.Active
+	}
+	p := profiles.Get(name)
+	if p == nil {
+		return "", fmt.Errorf("no profile named %q", name)
+	}
+	return dirs.ProfileDatabase(p.Name), nil
+}
+
+// dryRunMigrations lists the migrations the database is missing and checks
+// that they apply cleanly, returning the exit code
+func dryRunMigrations(path string) int {
+	// Opening a missing file would create an empty database
+	if _, err := os.Stat(path); err != nil {
+		fmt.Println("Error: no database to check:", err)
+		return 1
+	}
+	store, err := database.Open(path)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	defer store.Close()
+	version, err := store.SchemaVersion()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+
+	pending, err := store.Migrate(true)
+	fmt.Printf("Schema version %d, %d pending migrations\n", version, len(pending))
+	for _, migration := range pending {
+		fmt.Printf("  %d: %s\n", migration.Version, migration.Description)
+	}
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	return 0
+}
+
+// rebuildSearch migrates the database and reindexes it for full-text search,
+// returning the exit code
+func rebuildSearch(path string) int {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	store, err := database.NewStore(path)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	defer store.Close()
+	if err := store.RebuildFTS(); err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	fmt.Println("Search index rebuilt")
+	return 0
+}
@@ -1,20 +1,39 @@
 package tests
 
 import (
+	"errors"
+	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"gorm.io/gorm"
+
+	"track-my-job-apps/internal/config"
 	"track-my-job-apps/internal/database"
 	"track-my-job-apps/internal/models"
 	"track-my-job-apps/internal/parser"
+	"track-my-job-apps/internal/salary"
 )
 
-func TestIntegrationParseAndSave(t *testing.T) {
-	// Initialize test database
-	err := database.InitDatabase()
+// newTestStore opens a migrated in-memory database that is closed when the
+// test ends, so tests do not share data and can run in parallel
+func newTestStore(t *testing.T) *database.Store {
+	t.Helper()
+	store, err := database.NewStore(database.MemoryDSN)
 	if err != nil {
 		t.Fatalf("Failed to initialize database: %v", err)
 	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestIntegrationParseAndSave(t *testing.T) {
+	t.Parallel()
+
+	// Initialize test database
+	store := newTestStore(t)
 
 	// Test data
 	testHTML := `
@@ -40,7 +59,7 @@ func TestIntegrationParseAndSave(t *testing.T) {
 	}
 
 	// Save to database
-	err = database.CreateApp(result)
+	err = store.CreateApp(result)
 	if err != nil {
 		t.Fatalf("Failed to save job: %v", err)
 	}
@@ -51,7 +70,7 @@ func TestIntegrationParseAndSave(t *testing.T) {
 	}
 
 	// Retrieve it back
-	retrieved, err := database.GetAppByID(result.AppId)
+	retrieved, err := store.GetAppByID(result.AppId)
 	if err != nil {
 		t.Fatalf("Failed to retrieve job: %v", err)
 	}
@@ -66,6 +85,9 @@ func TestIntegrationParseAndSave(t *testing.T) {
 	if retrieved.Location != "Seattle, WA" {
 		t.Errorf("Expected location 'Seattle, WA', got '%s'", retrieved.Location)
 	}
+	if retrieved.SourceURL != "https://example.com/job/integration-test" {
+		t.Errorf("Expected source URL to be saved, got '%s'", retrieved.SourceURL)
+	}
 
 	t.Logf("Integration test successful:")
 	t.Logf("  Saved and retrieved job ID: %d", retrieved.AppId)
@@ -73,3 +95,584 @@ func TestIntegrationParseAndSave(t *testing.T) {
 	t.Logf("  Company: %s", retrieved.Company)
 	t.Logf("  Location: %s", retrieved.Location)
 }
+
+func TestIntegrationDuplicateSave(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+
+	app := models.JobApplication{Company: "DupeCorp", Position: "Duplicate Test Engineer", DateApplied: models.DateOnly{Time: time.Now()}}
+	first := app
+	if err := store.CreateApp(&first); err != nil {
+		t.Fatalf("Failed to save job: %v", err)
+	}
+
+	second := app
+	err := store.CreateApp(&second)
+	if !errors.Is(err, database.ErrDuplicateApp) {
+		t.Fatalf("Expected ErrDuplicateApp for a same-day duplicate, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Duplicate Test Engineer at DupeCorp") {
+		t.Errorf("Expected the error to name the application, got: %v", err)
+	}
+}
+
+func TestIntegrationStatusTimeline(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+
+	app := &models.JobApplication{Company: "TimelineCorp", Position: "Status Test Engineer", Status: models.SUBMITTED, DateApplied: models.DateOnly{Time: time.Now()}}
+	if err := store.CreateApp(app); err != nil {
+		t.Fatalf("Failed to save job: %v", err)
+	}
+
+	if _, err := store.UpdateAppStatus(app.AppId, models.PHONE_SCREEN, "Recruiter call booked"); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+	if _, err := store.UpdateAppStatus(app.AppId, models.REJECTED, ""); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+	// Setting the same status again is not a change
+	if event, err := store.UpdateAppStatus(app.AppId, models.REJECTED, ""); err != nil || event != nil {
+		t.Errorf("Expected no event for an unchanged status, got %v, %v", event, err)
+	}
+
+	events, err := store.GetStatusEvents(app.AppId)
+	if err != nil {
+		t.Fatalf("Failed to get status events: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 status events, got %d", len(events))
+	}
+	if events[0].FromStatus != models.SUBMITTED || events[0].ToStatus != models.PHONE_SCREEN || events[0].Note != "Recruiter call booked" {
+		t.Errorf("Unexpected first event: %+v", events[0])
+	}
+	if events[1].FromStatus != models.PHONE_SCREEN || events[1].ToStatus != models.REJECTED {
+		t.Errorf("Unexpected second event: %+v", events[1])
+	}
+
+	retrieved, err := store.GetAppByID(app.AppId)
+	if err != nil {
+		t.Fatalf("Failed to retrieve job: %v", err)
+	}
+	if retrieved.Status != models.REJECTED {
+		t.Errorf("Expected status REJECTED, got %s", retrieved.Status)
+	}
+
+	// The timeline is kept while the app is in the trash and purged with it
+	if err := store.DeleteApp(app.AppId); err != nil {
+		t.Fatalf("Failed to delete job: %v", err)
+	}
+	if events, _ := store.GetStatusEvents(app.AppId); len(events) != 2 {
+		t.Errorf("Expected status events to be kept in the trash, got %d", len(events))
+	}
+	if err := store.PurgeApp(app.AppId); err != nil {
+		t.Fatalf("Failed to purge job: %v", err)
+	}
+	if events, _ := store.GetStatusEvents(app.AppId); len(events) != 0 {
+		t.Errorf("Expected status events to be purged with the app, got %d", len(events))
+	}
+}
+
+func TestIntegrationLegacyMigration(t *testing.T) {
+	// A database created before versioned migrations, with a hand-edited
+	// status, a timestamp date and a source URL in the notes
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "job_apps.db")
+	store, err := database.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer store.Close()
+	err = store.DB().Exec("CREATE TABLE `apps` (`app_id` integer PRIMARY KEY AUTOINCREMENT,`company` varchar(255) NOT NULL," +
+		"`position` varchar(255) NOT NULL,`location` varchar(255),`salary_range` varchar(100),`workplace_type` varchar(50)," +
+		"`status` varchar(50) DEFAULT \"SUBMITTED\",`notes` text,`website` varchar(500),`date_applied` datetime)").Error
+	if err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+	err = store.DB().Exec("INSERT INTO apps (company, position, status, notes, date_applied) VALUES (?, ?, ?, ?, ?)",
+		"LegacyCorp", "Migration Test Engineer", "phone screen",
+		"Great team\nSource URL: https://boards.greenhouse.io/acme/jobs/123?gh_src=abc", "2024-03-04 00:00:00+00:00").Error
+	if err != nil {
+		t.Fatalf("Failed to insert legacy app: %v", err)
+	}
+
+	// A dry run reports every migration without changing anything
+	pending, err := store.Migrate(true)
+	if err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if len(pending) == 0 || pending[0].Version != 1 {
+		t.Fatalf("Expected every migration to be pending, got %+v", pending)
+	}
+	if version, _ := store.SchemaVersion(); version != 0 {
+		t.Errorf("Expected a dry run to leave schema version 0, got %d", version)
+	}
+	if store.DB().Migrator().HasTable("status_events") {
+		t.Errorf("Expected a dry run to roll back its changes")
+	}
+
+	applied, err := store.Migrate(false)
+	if err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if len(applied) != len(pending) {
+		t.Errorf("Expected %d migrations to be applied, got %d", len(pending), len(applied))
+	}
+	if version, _ := store.SchemaVersion(); version != pending[len(pending)-1].Version {
+		t.Errorf("Expected schema version %d, got %d", pending[len(pending)-1].Version, version)
+	}
+	if copies, _ := filepath.Glob(path + ".v0-*.bak"); len(copies) != 1 {
+		t.Errorf("Expected one copy of the database before migrating, got %v", copies)
+	}
+
+	var apps []models.JobApplication
+	if err := store.DB().Find(&apps).Error; err != nil || len(apps) != 1 {
+		t.Fatalf("Failed to retrieve migrated job: %v, %d apps", err, len(apps))
+	}
+	app := apps[0]
+	if app.Status != models.PHONE_SCREEN {
+		t.Errorf("Expected legacy status to become PHONE_SCREEN, got %s", app.Status)
+	}
+	if got := app.DateApplied.Format("2006-01-02"); got != "2024-03-04" {
+		t.Errorf("Expected date applied 2024-03-04, got %s", got)
+	}
+	if app.Notes != "Great team" || app.BoardJobId != "123" || app.CanonicalURL != "https://boards.greenhouse.io/acme/jobs/123" {
+		t.Errorf("Expected the source URL to move out of the notes, got %q, %q, %q", app.Notes, app.BoardJobId, app.CanonicalURL)
+	}
+
+	events, err := store.GetStatusEvents(app.AppId)
+	if err != nil {
+		t.Fatalf("Failed to get status events: %v", err)
+	}
+	if len(events) != 1 || events[0].ToStatus != models.PHONE_SCREEN {
+		t.Fatalf("Expected one backfilled PHONE_SCREEN event, got %+v", events)
+	}
+	if got := events[0].ChangedAt.Format("2006-01-02"); got != "2024-03-04" {
+		t.Errorf("Expected backfilled event on the date applied, got %s", got)
+	}
+
+	// Migrating again has nothing to do
+	if applied, err := store.Migrate(false); err != nil || len(applied) != 0 {
+		t.Errorf("Expected no pending migrations, got %d, %v", len(applied), err)
+	}
+}
+
+func TestIntegrationSchemaMatchesModels(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+
+	// Every model field needs a migration that creates its column
+	for _, model := range []interface{}{&models.JobApplication{}, &models.StatusEvent{}, &models.Operation{}, &database.SavedSearch{}} {
+		stmt := &gorm.Statement{DB: store.DB()}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatalf("Failed to parse %T: %v", model, err)
+		}
+		for _, column := range stmt.Schema.DBNames {
+			if !store.DB().Migrator().HasColumn(model, column) {
+				t.Errorf("Table %s has no column %s; add a migration", stmt.Schema.Table, column)
+			}
+		}
+	}
+}
+
+func TestIntegrationUpdateConflict(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+
+	applied := models.DateOnly{Time: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+	first := &models.JobApplication{Company: "ConflictCorp", Position: "Engineer", DateApplied: applied}
+	second := &models.JobApplication{Company: "ConflictCorp", Position: "Engineer II", DateApplied: applied}
+	for _, app := range []*models.JobApplication{first, second} {
+		if err := store.CreateApp(app); err != nil {
+			t.Fatalf("Failed to save job: %v", err)
+		}
+	}
+
+	// Renaming the second application onto the first breaks the unique index
+	second.Position = "Engineer"
+	err := store.UpdateApp(second)
+	if !errors.Is(err, database.ErrDuplicateApp) {
+		t.Fatalf("Expected ErrDuplicateApp, got %v", err)
+	}
+	if !strings.Contains(err.Error(), fmt.Sprintf("application #%d", first.AppId)) {
+		t.Errorf("Expected the error to name application #%d, got: %v", first.AppId, err)
+	}
+}
+
+func TestIntegrationTrash(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+
+	applied := models.DateOnly{Time: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)}
+	app := models.JobApplication{Company: "TrashCorp", Position: "Soft Delete Engineer", DateApplied: applied}
+	first := app
+	if err := store.CreateApp(&first); err != nil {
+		t.Fatalf("Failed to save job: %v", err)
+	}
+	if err := store.DeleteApp(first.AppId); err != nil {
+		t.Fatalf("Failed to delete job: %v", err)
+	}
+
+	// Deleted applications are only found in the trash
+	if _, err := store.GetAppByID(first.AppId); err == nil {
+		t.Errorf("Expected a deleted job not to be found")
+	}
+	if !containsApp(store.GetTrash, first.AppId) {
+		t.Errorf("Expected job %d in the trash", first.AppId)
+	}
+	if containsApp(store.ListApps, first.AppId) {
+		t.Errorf("Expected job %d to be left out of the list", first.AppId)
+	}
+
+	// The same application can be saved again, which blocks restoring the deleted one
+	second := app
+	if err := store.CreateApp(&second); err != nil {
+		t.Fatalf("Failed to save job again after deleting it: %v", err)
+	}
+	if err := store.RestoreApp(first.AppId); !errors.Is(err, database.ErrDuplicateApp) {
+		t.Errorf("Expected ErrDuplicateApp restoring over a saved job, got %v", err)
+	}
+
+	if err := store.DeleteApp(second.AppId); err != nil {
+		t.Fatalf("Failed to delete job: %v", err)
+	}
+	if err := store.RestoreApp(first.AppId); err != nil {
+		t.Fatalf("Failed to restore job: %v", err)
+	}
+	if _, err := store.GetAppByID(first.AppId); err != nil {
+		t.Errorf("Expected restored job to be found: %v", err)
+	}
+
+	// Only applications deleted before the cutoff are purged
+	if _, err := store.PurgeDeletedApps(time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("Failed to purge trash: %v", err)
+	}
+	if !containsApp(store.GetTrash, second.AppId) {
+		t.Errorf("Expected recently deleted job %d to stay in the trash", second.AppId)
+	}
+	if _, err := store.PurgeDeletedApps(time.Now()); err != nil {
+		t.Fatalf("Failed to purge trash: %v", err)
+	}
+	if containsApp(store.GetTrash, second.AppId) {
+		t.Errorf("Expected job %d to be purged", second.AppId)
+	}
+}
+
+// containsApp reports whether the apps returned by list include id
+func containsApp(list func() ([]models.JobApplication, error), id uint) bool {
+	apps, err := list()
+	if err != nil {
+		return false
+	}
+	for _, app := range apps {
+		if app.AppId == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestIntegrationUndoRedo(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+
+	app := &models.JobApplication{Company: "UndoCorp", Position: "Undo Test Engineer", Status: models.SUBMITTED, Notes: "Referred by Sam", DateApplied: models.DateOnly{Time: time.Now()}}
+	if err := store.CreateApp(app); err != nil {
+		t.Fatalf("Failed to save job: %v", err)
+	}
+	if err := store.RecordOperation(models.CREATE, nil, app, "Saved", 10); err != nil {
+		t.Fatalf("Failed to record operation: %v", err)
+	}
+
+	// A status change that also wipes the notes
+	edited := *app
+	edited.Status = models.PHONE_SCREEN
+	edited.Notes = ""
+	if err := store.UpdateApp(&edited); err != nil {
+		t.Fatalf("Failed to update job: %v", err)
+	}
+	if err := store.RecordOperation(models.UPDATE, app, &edited, "Edited", 10); err != nil {
+		t.Fatalf("Failed to record operation: %v", err)
+	}
+
+	op, err := store.UndoOperation()
+	if err != nil || op == nil || op.Summary != "Edited" {
+		t.Fatalf("Expected to undo the edit, got %+v, %v", op, err)
+	}
+	retrieved, err := store.GetAppByID(app.AppId)
+	if err != nil {
+		t.Fatalf("Failed to retrieve job: %v", err)
+	}
+	if retrieved.Notes != "Referred by Sam" || retrieved.Status != models.SUBMITTED {
+		t.Errorf("Expected notes and status to be restored, got %q, %s", retrieved.Notes, retrieved.Status)
+	}
+	events, _ := store.GetStatusEvents(app.AppId)
+	if len(events) != 1 || events[0].ToStatus != models.SUBMITTED || events[0].Note != "Undo" {
+		t.Errorf("Expected one Undo status event, got %+v", events)
+	}
+
+	// Undoing the create moves the application to the trash
+	if op, err := store.UndoOperation(); err != nil || op == nil || op.Kind != models.CREATE {
+		t.Fatalf("Expected to undo the create, got %+v, %v", op, err)
+	}
+	if _, err := store.GetAppByID(app.AppId); err == nil {
+		t.Errorf("Expected the undone create to be in the trash")
+	}
+
+	// Redo applies the operations again in order
+	if op, err := store.RedoOperation(); err != nil || op == nil || op.Kind != models.CREATE {
+		t.Fatalf("Expected to redo the create, got %+v, %v", op, err)
+	}
+	if op, err := store.RedoOperation(); err != nil || op == nil || op.Kind != models.UPDATE {
+		t.Fatalf("Expected to redo the edit, got %+v, %v", op, err)
+	}
+	if retrieved, _ := store.GetAppByID(app.AppId); retrieved == nil || retrieved.Notes != "" || retrieved.Status != models.PHONE_SCREEN {
+		t.Errorf("Expected the edit to be applied again, got %+v", retrieved)
+	}
+	if op, err := store.RedoOperation(); err != nil || op != nil {
+		t.Errorf("Expected nothing to redo, got %+v, %v", op, err)
+	}
+
+	// A new change after an undo drops the redo, and only depth operations are kept
+	if _, err := store.UndoOperation(); err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := store.RecordOperation(models.DELETE, nil, app, fmt.Sprintf("Change %d", i), 2); err != nil {
+			t.Fatalf("Failed to record operation: %v", err)
+		}
+	}
+	if op, err := store.RedoOperation(); err != nil || op != nil {
+		t.Errorf("Expected a new change to drop the redo, got %+v, %v", op, err)
+	}
+	var kept int64
+	store.DB().Model(&models.Operation{}).Count(&kept)
+	if kept != 2 {
+		t.Errorf("Expected 2 operations to be kept, got %d", kept)
+	}
+}
+
+func TestIntegrationFullTextSearch(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+
+	app := &models.JobApplication{Company: "SearchCorp", Position: "Search Test Engineer", Description: "You will tune the quokkaindex ranking service.", DateApplied: models.DateOnly{Time: time.Now()}}
+	if err := store.CreateApp(app); err != nil {
+		t.Fatalf("Failed to save job: %v", err)
+	}
+
+	// New applications are indexed by the insert trigger
+	results, err := store.SearchApps("quokkaindex")
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+	if len(results) != 1 || results[0].AppId != app.AppId {
+		t.Fatalf("Expected to find job %d, got %+v", app.AppId, results)
+	}
+	if want := database.SnippetStart + "quokkaindex" + database.SnippetEnd; !strings.Contains(results[0].Snippet, want) {
+		t.Errorf("Expected the snippet to mark the match, got %q", results[0].Snippet)
+	}
+
+	// Edits replace the indexed text
+	app.Description = "You will tune the wombatindex ranking service."
+	if err := store.UpdateApp(app); err != nil {
+		t.Fatalf("Failed to update job: %v", err)
+	}
+	if results, _ := store.SearchApps("quokkaindex"); len(results) != 0 {
+		t.Errorf("Expected the old description to be dropped from the index, got %d results", len(results))
+	}
+	if results, _ := store.SearchApps("wombat*"); len(results) != 1 {
+		t.Errorf("Expected a prefix query to find the new description, got %d results", len(results))
+	}
+
+	// Trashed applications leave the index and come back when restored
+	if err := store.DeleteApp(app.AppId); err != nil {
+		t.Fatalf("Failed to delete job: %v", err)
+	}
+	if results, _ := store.SearchApps("wombatindex"); len(results) != 0 {
+		t.Errorf("Expected a trashed job not to be found, got %d results", len(results))
+	}
+	if err := store.RestoreApp(app.AppId); err != nil {
+		t.Fatalf("Failed to restore job: %v", err)
+	}
+	if results, _ := store.SearchApps("company:searchcorp AND wombatindex"); len(results) != 1 {
+		t.Errorf("Expected a restored job to be found, got %d results", len(results))
+	}
+
+	if err := store.RebuildFTS(); err != nil {
+		t.Fatalf("Failed to rebuild search index: %v", err)
+	}
+	if results, _ := store.SearchApps("wombatindex"); len(results) != 1 {
+		t.Errorf("Expected the rebuilt index to find the job, got %d results", len(results))
+	}
+
+	if _, err := store.SearchApps(`"unclosed`); err == nil {
+		t.Errorf("Expected an error for invalid query syntax")
+	}
+}
+
+func TestIntegrationQuery(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+
+	day := func(d int) models.DateOnly {
+		return models.DateOnly{Time: time.Date(2023, 1, d, 0, 0, 0, 0, time.UTC)}
+	}
+	apps := []models.JobApplication{
+		{Company: "QueryCorp Alpha", Position: "Backend Engineer", Location: "Denver, CO", WorkplaceType: "Remote", Status: models.SUBMITTED, DateApplied: day(1),
+			SalaryMin: 150000, SalaryMax: 170000, SalaryCurrency: "USD", SalaryPeriod: models.ANNUAL, Tags: models.Tags{"golang", "remote"}},
+		{Company: "QueryCorp Beta", Position: "Platform Engineer", Location: "Austin, TX", WorkplaceType: "Hybrid", Status: models.PHONE_SCREEN, DateApplied: day(5),
+			SalaryMin: 60, SalaryMax: 70, SalaryCurrency: "USD", SalaryPeriod: models.HOURLY, Tags: models.Tags{"Golang"}},
+		{Company: "QueryCorp Gamma", Position: "Frontend Engineer", Location: "Denver, CO", WorkplaceType: "On-site", Status: models.REJECTED, DateApplied: day(10)},
+		{Company: "QueryCorp Delta", Position: "Data Engineer", Location: "Remote", WorkplaceType: "Remote", Status: models.SUBMITTED, DateApplied: day(15),
+			SalaryMin: 200000, SalaryCurrency: "USD", SalaryPeriod: models.ANNUAL},
+	}
+	for i := range apps {
+		if err := store.CreateApp(&apps[i]); err != nil {
+			t.Fatalf("Failed to save job: %v", err)
+		}
+	}
+
+	companies := func(page *database.Page) string {
+		var names []string
+		for _, app := range page.Apps {
+			names = append(names, strings.TrimPrefix(app.Company, "QueryCorp "))
+		}
+		return strings.Join(names, ",")
+	}
+	annual := salary.AnnualSQL(config.Default())
+
+	tests := []struct {
+		name     string
+		query    database.Query
+		expected string
+		total    int64
+	}{
+		{"newest first by default", database.Query{}, "Delta,Gamma,Beta,Alpha", 4},
+		{"status", database.Query{Statuses: []models.Status{models.SUBMITTED}}, "Delta,Alpha", 2},
+		{"date range", database.Query{AppliedFrom: day(5), AppliedTo: day(10)}, "Gamma,Beta", 2},
+		{"workplace type", database.Query{WorkplaceTypes: []string{"remote", "hybrid"}}, "Delta,Beta,Alpha", 3},
+		{"location", database.Query{Location: "denver"}, "Gamma,Alpha", 2},
+		{"position", database.Query{Position: "end engineer"}, "Gamma,Alpha", 2},
+		{"annualized salary range", database.Query{SalaryMin: 130000, SalaryMax: 180000, AnnualSalarySQL: annual}, "Beta,Alpha", 2},
+		{"tags", database.Query{Tags: []string{"golang", "REMOTE"}}, "Alpha", 1},
+		{"salary sort puts missing salaries last", database.Query{Sort: []database.SortField{{Field: "salary", Desc: true}}, AnnualSalarySQL: annual}, "Delta,Alpha,Beta,Gamma", 4},
+		{"multi-field sort", database.Query{Sort: []database.SortField{{Field: "location"}, {Field: "dateApplied", Desc: true}}}, "Beta,Gamma,Alpha,Delta", 4},
+		{"page with total", database.Query{Limit: 2, Offset: 1}, "Gamma,Beta", 4},
+	}
+	for _, test := range tests {
+		test.query.Company = "QueryCorp"
+		page, err := store.QueryApps(test.query)
+		if err != nil {
+			t.Errorf("%s: query failed: %v", test.name, err)
+			continue
+		}
+		if got := companies(page); got != test.expected || page.Total != test.total {
+			t.Errorf("%s: expected %s of %d, got %s of %d", test.name, test.expected, test.total, got, page.Total)
+		}
+	}
+
+	if _, err := store.QueryApps(database.Query{Sort: []database.SortField{{Field: "notes; DROP TABLE apps"}}}); err == nil {
+		t.Errorf("Expected an error sorting by an unknown field")
+	}
+}
+
+func TestIntegrationSavedSearches(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+
+	// The example views are seeded and pinned
+	searches, err := store.ListSavedSearches()
+	if err != nil {
+		t.Fatalf("Failed to list saved searches: %v", err)
+	}
+	if len(searches) < 3 || searches[0].Name != "Active interviews" || !searches[0].Pinned {
+		t.Fatalf("Expected the pinned example views first, got %+v", searches)
+	}
+
+	daysAgo := func(days int) models.DateOnly {
+		return models.DateOnly{Time: time.Now().AddDate(0, 0, -days)}
+	}
+	for _, app := range []*models.JobApplication{
+		{Company: "SavedCorp Recent", Position: "Engineer", Status: models.SUBMITTED, DateApplied: daysAgo(3)},
+		{Company: "SavedCorp Stale", Position: "Engineer", Status: models.SUBMITTED, DateApplied: daysAgo(30)},
+	} {
+		if err := store.CreateApp(app); err != nil {
+			t.Fatalf("Failed to save job: %v", err)
+		}
+	}
+
+	search := &database.SavedSearch{Name: "  SavedCorp stale  ", Query: database.Query{Company: "SavedCorp", AppliedMoreThanDaysAgo: 21}}
+	if err := store.SaveSearch(search); err != nil {
+		t.Fatalf("Failed to save search: %v", err)
+	}
+	if search.Name != "SavedCorp stale" {
+		t.Errorf("Expected the name to be trimmed, got %q", search.Name)
+	}
+
+	saved, err := store.GetSavedSearch(search.Id)
+	if err != nil {
+		t.Fatalf("Failed to get saved search: %v", err)
+	}
+	if count, err := store.CountApps(saved.Query); err != nil || count != 1 {
+		t.Errorf("Expected 1 application applied over 21 days ago, got %d (%v)", count, err)
+	}
+
+	// Views are updated in place and follow relative dates
+	saved.Query.AppliedMoreThanDaysAgo, saved.Query.AppliedWithinDays = 0, 7
+	saved.Pinned = true
+	if err := store.SaveSearch(saved); err != nil {
+		t.Fatalf("Failed to update saved search: %v", err)
+	}
+	page, err := store.QueryApps(saved.Query)
+	if err != nil {
+		t.Fatalf("Failed to run saved search: %v", err)
+	}
+	if len(page.Apps) != 1 || page.Apps[0].Company != "SavedCorp Recent" {
+		t.Errorf("Expected only the recent application, got %+v", page.Apps)
+	}
+
+	if err := store.SaveSearch(&database.SavedSearch{Name: "SavedCorp stale"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected a duplicate name error, got %v", err)
+	}
+	if err := store.SaveSearch(&database.SavedSearch{Name: " "}); err == nil {
+		t.Errorf("Expected an error saving a search without a name")
+	}
+
+	if err := store.DeleteSavedSearch(saved.Id); err != nil {
+		t.Fatalf("Failed to delete saved search: %v", err)
+	}
+	if _, err := store.GetSavedSearch(saved.Id); err == nil {
+		t.Errorf("Expected the saved search to be deleted")
+	}
+	if err := store.DeleteSavedSearch(saved.Id); err == nil {
+		t.Errorf("Expected an error deleting a saved search twice")
+	}
+}
+
+func TestIntegrationSeparateStores(t *testing.T) {
+	t.Parallel()
+	first := newTestStore(t)
+	second := newTestStore(t)
+
+	app := &models.JobApplication{Company: "StoreCorp", Position: "Engineer", DateApplied: models.DateOnly{Time: time.Now()}}
+	if err := first.CreateApp(app); err != nil {
+		t.Fatalf("Failed to save job: %v", err)
+	}
+	if apps, err := second.ListApps(); err != nil || len(apps) != 0 {
+		t.Errorf("Expected the second in-memory store to be empty, got %d apps (%v)", len(apps), err)
+	}
+	if first.Path() != "" {
+		t.Errorf("Expected no file path for an in-memory store, got %q", first.Path())
+	}
+
+	path := filepath.Join(t.TempDir(), "job_apps.db")
+	store, err := database.NewStore(path + "?_pragma=busy_timeout(5000)")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer store.Close()
+	if store.Path() != path {
+		t.Errorf("Expected path %s, got %s", path, store.Path())
+	}
+}